@@ -0,0 +1,42 @@
+package gostl
+
+// 有序映射，按键升序组织键值对
+//
+//	list.SkipList 与 tree.Map 均实现该接口，可互相替换
+type OrderedMap[K any, V any] interface {
+	// Len 返回键值对的数量
+	Len() int
+	// Clear 清空所有键值对
+	Clear()
+	// Insert 插入一对键值对，如果键已经存在，则更新对应的值
+	Insert(key K, value V)
+	// Remove 删除指定键对应的键值对，如果键不存在，返回 false
+	Remove(key K) bool
+	// Find 返回指定键对应的值的引用，如果键不存在，返回 nil
+	Find(key K) *V
+	// LowerBound 返回第一个不小于 key 的键及其值的引用，如果不存在，值的引用为 nil
+	LowerBound(key K) (K, *V)
+	// ForEach 按键升序遍历，并为每个键值对执行 f 函数
+	ForEach(f func(key K, value *V))
+}
+
+// 有序集合，按升序组织键
+//
+//	set.SkipListSet、set.TreeSet 与 tree 包中的 RBTree、AVLTree、Treap、SplayTree 均实现该接口，可互相替换；
+//	各实现的 Insert 签名不同（tree 包中不返回值，set 包中返回 bool），因此接口使用 TryInsert
+type OrderedSet[K any] interface {
+	// Len 返回键的数量
+	Len() int
+	// Clear 清空所有键
+	Clear()
+	// TryInsert 插入指定键，如果键已经存在，返回 false
+	TryInsert(key K) bool
+	// Remove 删除指定键，如果键不存在，返回 false
+	Remove(key K) bool
+	// Exist 判断是否存在指定键
+	Exist(key K) bool
+	// LowerBound 返回第一个不小于 key 的键，如果不存在，返回 false
+	LowerBound(key K) (K, bool)
+	// ForEach 按升序遍历，并为每个键执行 f 函数
+	ForEach(f func(key K))
+}
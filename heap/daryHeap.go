@@ -0,0 +1,169 @@
+package heap
+
+import "gostl"
+
+// d 叉最小堆：下标为 i 的节点的子节点下标为 d*i+1 ~ d*i+d，父节点下标为 (i-1)/d
+//
+//	相比二叉堆，d 叉堆的高度更低，插入更快，弹出时每层需要比较更多的子节点，适合插入远多于弹出的场景
+
+// NewDaryMinHeap 将 array 数组构建为 d 叉最小堆，时间复杂度 O(array.Len())
+func NewDaryMinHeap[T gostl.Ordered](array *[]T, d int) {
+	n := len(*array)
+	for i := (n - 2) / d; i >= 0; i-- {
+		daryHeapDown(array, i, n, d)
+	}
+}
+
+// IsDaryMinHeap 判断 array 数组是否为 d 叉最小堆，时间复杂度 O(array.Len())
+func IsDaryMinHeap[T gostl.Ordered](array []T, d int) bool {
+	for child := 1; child < len(array); child++ {
+		if array[child] < array[(child-1)/d] {
+			return false
+		}
+	}
+	return true
+}
+
+// PushDaryMinHeap 将元素插入到 d 叉最小堆，时间复杂度 O(log_d(heap.Len()))
+func PushDaryMinHeap[T gostl.Ordered](heap *[]T, value T, d int) {
+	*heap = append(*heap, value)
+	daryHeapUp(heap, len(*heap)-1, d)
+}
+
+// PopDaryMinHeap 删除并返回 d 叉最小堆的根元素，时间复杂度 O(d*log_d(heap.Len()))
+func PopDaryMinHeap[T gostl.Ordered](heap *[]T, d int) T {
+	h := *heap
+	n := len(h) - 1
+	heapSwap(&h, 0, n)
+	daryHeapDown(&h, 0, n, d)
+	*heap = h[0:n]
+	return h[n]
+}
+
+// RemoveDaryMinHeap 删除并返回 d 叉最小堆中的指定元素，时间复杂度 O(d*log_d(heap.Len()))
+func RemoveDaryMinHeap[T gostl.Ordered](heap *[]T, idx int, d int) T {
+	h := *heap
+	n := len(h) - 1
+	if n != idx {
+		heapSwap(&h, idx, n)
+		if !daryHeapDown(&h, idx, n, d) {
+			daryHeapUp(&h, idx, d)
+		}
+	}
+	*heap = h[0:n]
+	return h[n]
+}
+
+func daryHeapUp[T gostl.Ordered](heap *[]T, j, d int) {
+	for j > 0 {
+		i := (j - 1) / d
+		if !((*heap)[j] < (*heap)[i]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		j = i
+	}
+}
+
+func daryHeapDown[T gostl.Ordered](heap *[]T, i0, n, d int) bool {
+	i := i0
+	for {
+		j1 := d*i + 1
+		if j1 >= n || j1 < 0 {
+			break
+		}
+		j := j1
+		for k, end := j1+1, min(j1+d, n); k < end; k++ {
+			if (*heap)[k] < (*heap)[j] {
+				j = k
+			}
+		}
+		if !((*heap)[j] < (*heap)[i]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		i = j
+	}
+	return i > i0
+}
+
+// NewDaryMinHeapFunc 基于 less 函数将 array 数组构建为 d 叉最小堆，时间复杂度 O(array.Len())
+func NewDaryMinHeapFunc[T any](array *[]T, d int, less gostl.LessFunc[T]) {
+	n := len(*array)
+	for i := (n - 2) / d; i >= 0; i-- {
+		daryHeapDownFunc(array, i, n, d, less)
+	}
+}
+
+// IsDaryMinHeapFunc 基于 less 函数判断 array 数组是否为 d 叉最小堆，时间复杂度 O(array.Len())
+func IsDaryMinHeapFunc[T any](array []T, d int, less gostl.LessFunc[T]) bool {
+	for child := 1; child < len(array); child++ {
+		if less(array[child], array[(child-1)/d]) {
+			return false
+		}
+	}
+	return true
+}
+
+// PushDaryMinHeapFunc 基于 less 函数将元素插入到 d 叉最小堆，时间复杂度 O(log_d(heap.Len()))
+func PushDaryMinHeapFunc[T any](heap *[]T, value T, d int, less gostl.LessFunc[T]) {
+	*heap = append(*heap, value)
+	daryHeapUpFunc(heap, len(*heap)-1, d, less)
+}
+
+// PopDaryMinHeapFunc 基于 less 函数删除并返回 d 叉最小堆的根元素，时间复杂度 O(d*log_d(heap.Len()))
+func PopDaryMinHeapFunc[T any](heap *[]T, d int, less gostl.LessFunc[T]) T {
+	h := *heap
+	n := len(h) - 1
+	heapSwap(&h, 0, n)
+	daryHeapDownFunc(&h, 0, n, d, less)
+	*heap = h[0:n]
+	return h[n]
+}
+
+// RemoveDaryMinHeapFunc 基于 less 函数删除并返回 d 叉最小堆中的指定元素，时间复杂度 O(d*log_d(heap.Len()))
+func RemoveDaryMinHeapFunc[T any](heap *[]T, idx int, d int, less gostl.LessFunc[T]) T {
+	h := *heap
+	n := len(h) - 1
+	if n != idx {
+		heapSwap(&h, idx, n)
+		if !daryHeapDownFunc(&h, idx, n, d, less) {
+			daryHeapUpFunc(&h, idx, d, less)
+		}
+	}
+	*heap = h[0:n]
+	return h[n]
+}
+
+func daryHeapUpFunc[T any](heap *[]T, j, d int, less gostl.LessFunc[T]) {
+	for j > 0 {
+		i := (j - 1) / d
+		if !less((*heap)[j], (*heap)[i]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		j = i
+	}
+}
+
+func daryHeapDownFunc[T any](heap *[]T, i0, n, d int, less gostl.LessFunc[T]) bool {
+	i := i0
+	for {
+		j1 := d*i + 1
+		if j1 >= n || j1 < 0 {
+			break
+		}
+		j := j1
+		for k, end := j1+1, min(j1+d, n); k < end; k++ {
+			if less((*heap)[k], (*heap)[j]) {
+				j = k
+			}
+		}
+		if !less((*heap)[j], (*heap)[i]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		i = j
+	}
+	return i > i0
+}
@@ -0,0 +1,258 @@
+package heap
+
+import (
+	"gostl"
+	"math/bits"
+)
+
+// FibonacciHeapHandle 斐波那契堆中元素的句柄，由 Push 返回，用于 DecreaseKey
+type FibonacciHeapHandle[T any] struct {
+	parent *FibonacciHeapHandle[T]
+	child  *FibonacciHeapHandle[T] // 任意一个子节点，子节点之间组成双向循环链表
+	left   *FibonacciHeapHandle[T]
+	right  *FibonacciHeapHandle[T]
+	degree int  // 子节点的数量
+	mark   bool // 成为子节点后是否失去过子节点
+	value  T
+}
+
+// Value 返回句柄对应的元素
+func (h *FibonacciHeapHandle[T]) Value() T {
+	return h.value
+}
+
+// FibonacciHeap 斐波那契堆（最小堆），Push、Top、Meld 的时间复杂度为 O(1)，
+// DecreaseKey 的均摊时间复杂度为 O(1)，Pop 的均摊时间复杂度为 O(log n)
+type FibonacciHeap[T any] struct {
+	min  *FibonacciHeapHandle[T] // 根链表中的最小节点
+	size int
+	impl fhImpl[T]
+}
+
+// NewFibonacciHeap 构造一个可比较类型的斐波那契堆
+func NewFibonacciHeap[T gostl.Ordered]() *FibonacciHeap[T] {
+	h := fhOrdered[T]{}
+	h.impl = (fhImpl[T])(&h)
+	return &h.FibonacciHeap
+}
+
+// NewFibonacciHeapFunc 基于比较函数 less 构造一个斐波那契堆
+func NewFibonacciHeapFunc[T any](less gostl.LessFunc[T]) *FibonacciHeap[T] {
+	h := fhFunc[T]{}
+	h.lessFunc = less
+	h.impl = (fhImpl[T])(&h)
+	return &h.FibonacciHeap
+}
+
+// Len 获取斐波那契堆中元素的数量
+func (h *FibonacciHeap[T]) Len() int {
+	return h.size
+}
+
+// Empty 判断斐波那契堆是否为空
+func (h *FibonacciHeap[T]) Empty() bool {
+	return h.size == 0
+}
+
+// Clear 清空斐波那契堆，所有句柄均失效
+func (h *FibonacciHeap[T]) Clear() {
+	h.min = nil
+	h.size = 0
+}
+
+// Top 获取斐波那契堆的最小元素，若斐波那契堆为空则 panic
+func (h *FibonacciHeap[T]) Top() T {
+	if h.min == nil {
+		panic("FibonacciHeap.Top: empty heap")
+	}
+	return h.min.value
+}
+
+// Push 向斐波那契堆插入元素，并返回该元素的句柄，时间复杂度 O(1)
+func (h *FibonacciHeap[T]) Push(value T) *FibonacciHeapHandle[T] {
+	node := &FibonacciHeapHandle[T]{value: value}
+	node.left = node
+	node.right = node
+	h.min = h.meldList(h.min, node)
+	h.size++
+	return node
+}
+
+// Pop 删除并返回斐波那契堆的最小元素，若斐波那契堆为空则 panic，均摊时间复杂度 O(log(h.Len()))
+func (h *FibonacciHeap[T]) Pop() T {
+	z := h.min
+	if z == nil {
+		panic("FibonacciHeap.Pop: empty heap")
+	}
+
+	// 将 z 的所有子节点提升到根链表
+	if child := z.child; child != nil {
+		node := child
+		for {
+			node.parent = nil
+			node = node.right
+			if node == child {
+				break
+			}
+		}
+		z.child = nil
+		h.meldList(z, child)
+	}
+
+	// 将 z 从根链表中移除
+	if z.right == z {
+		h.min = nil
+	} else {
+		z.left.right = z.right
+		z.right.left = z.left
+		h.min = z.right
+		h.consolidate()
+	}
+	z.left, z.right = nil, nil
+	h.size--
+	return z.value
+}
+
+// DecreaseKey 将句柄对应的元素修改为更小的 value，若 value 大于原值则 panic，均摊时间复杂度 O(1)
+//
+//	句柄必须属于当前斐波那契堆（或已被 Meld 进当前斐波那契堆）且尚未被弹出
+func (h *FibonacciHeap[T]) DecreaseKey(node *FibonacciHeapHandle[T], value T) {
+	if h.impl.less(node.value, value) {
+		panic("FibonacciHeap.DecreaseKey: new value is greater than the current value")
+	}
+	node.value = value
+	if parent := node.parent; parent != nil && h.impl.less(node.value, parent.value) {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+	if h.impl.less(node.value, h.min.value) {
+		h.min = node
+	}
+}
+
+// Meld 将 other 中的所有元素合并到 h 中并清空 other，other 中的句柄在 h 中仍然有效，时间复杂度 O(1)
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if h == other {
+		return
+	}
+	h.min = h.meldList(h.min, other.min)
+	h.size += other.size
+	other.min = nil
+	other.size = 0
+}
+
+// meldList 拼接两个双向循环链表，返回两个链表头中较小的节点
+func (h *FibonacciHeap[T]) meldList(a, b *FibonacciHeapHandle[T]) *FibonacciHeapHandle[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	a.right.left = b.left
+	b.left.right = a.right
+	a.right = b
+	b.left = a
+	if h.impl.less(b.value, a.value) {
+		return b
+	}
+	return a
+}
+
+// consolidate 合并根链表中度数相同的树，直到根链表中所有树的度数均不相同
+func (h *FibonacciHeap[T]) consolidate() {
+	roots := make([]*FibonacciHeapHandle[T], 0, 16)
+	for node := h.min; ; {
+		roots = append(roots, node)
+		node = node.right
+		if node == h.min {
+			break
+		}
+	}
+
+	// 度数不超过 log_φ(n)，φ 为黄金分割比
+	degrees := make([]*FibonacciHeapHandle[T], bits.Len(uint(h.size))*3/2+2)
+	for _, x := range roots {
+		x.left, x.right = x, x
+		for d := x.degree; degrees[d] != nil; d++ {
+			y := degrees[d]
+			if h.impl.less(y.value, x.value) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			degrees[d] = nil
+		}
+		degrees[x.degree] = x
+	}
+
+	h.min = nil
+	for _, x := range degrees {
+		if x != nil {
+			h.min = h.meldList(h.min, x)
+		}
+	}
+}
+
+// link 将根节点 y 作为 x 的子节点
+func (h *FibonacciHeap[T]) link(y, x *FibonacciHeapHandle[T]) {
+	y.left, y.right = y, y
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		x.child = y
+	} else {
+		h.meldList(x.child, y)
+	}
+	x.degree++
+}
+
+// cut 将 x 从其父节点 y 的子节点链表中剪下，并加入根链表
+func (h *FibonacciHeap[T]) cut(x, y *FibonacciHeapHandle[T]) {
+	if x.right == x {
+		y.child = nil
+	} else {
+		x.left.right = x.right
+		x.right.left = x.left
+		if y.child == x {
+			y.child = x.right
+		}
+	}
+	y.degree--
+
+	x.left, x.right = x, x
+	x.parent = nil
+	x.mark = false
+	h.meldList(h.min, x)
+}
+
+// cascadingCut 若 y 已经失去过一个子节点，则将其也剪下，并继续向上检查
+func (h *FibonacciHeap[T]) cascadingCut(y *FibonacciHeapHandle[T]) {
+	for z := y.parent; z != nil; y, z = z, z.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+	}
+}
+
+type fhImpl[T any] interface {
+	less(a, b T) bool
+}
+
+type fhOrdered[T gostl.Ordered] struct {
+	FibonacciHeap[T]
+}
+
+func (h *fhOrdered[T]) less(a, b T) bool {
+	return a < b
+}
+
+type fhFunc[T any] struct {
+	FibonacciHeap[T]
+	lessFunc gostl.LessFunc[T]
+}
+
+func (h *fhFunc[T]) less(a, b T) bool {
+	return h.lessFunc(a, b)
+}
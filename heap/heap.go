@@ -13,8 +13,8 @@ func NewMinHeap[T gostl.Ordered](array *[]T) {
 // IsMinHeap 判断 array 数组是否为最小堆，时间复杂度 O(array.Len())
 func IsMinHeap[T gostl.Ordered](array []T) bool {
 	parent := 0
-	for child := 0; child < len(array); child++ {
-		if array[parent] > array[child] {
+	for child := 1; child < len(array); child++ {
+		if array[child] < array[parent] {
 			return false
 		}
 		if (child & 1) == 0 {
@@ -59,34 +59,11 @@ func heapSwap[T any](heap *[]T, i, j int) {
 }
 
 func heapUp[T gostl.Ordered](heap *[]T, j int) {
-	for {
-		i := (j - 1) >> 1
-		if i == j || !((*heap)[j] < (*heap)[i]) {
-			break
-		}
-		heapSwap(heap, i, j)
-		j = i
-	}
+	SiftUpMinHeapFunc(*heap, j, lessOrdered[T], nil)
 }
 
 func heapDown[T gostl.Ordered](heap *[]T, i0, n int) bool {
-	i := i0
-	for {
-		j1 := i<<1 | 1
-		if j1 >= n || j1 < 0 {
-			break
-		}
-		j := j1
-		if j2 := j1 + 1; j2 < n && (*heap)[j2] < (*heap)[j1] {
-			j = j2
-		}
-		if !((*heap)[j] < (*heap)[i]) {
-			break
-		}
-		heapSwap(heap, i, j)
-		i = j
-	}
-	return i > 10
+	return SiftDownMinHeapFunc(*heap, i0, n, lessOrdered[T], nil)
 }
 
 // NewMinHeapFunc 基于 less 函数构建最小堆，时间复杂度 O(array.Len())
@@ -100,8 +77,8 @@ func NewMinHeapFunc[T any](array *[]T, less gostl.LessFunc[T]) {
 // IsMinHeapFunc 基于 less 函数判断 array 数组是否为最小堆，时间复杂度 O(array.Len())
 func IsMinHeapFunc[T any](array []T, less gostl.LessFunc[T]) bool {
 	parent := 0
-	for child := 0; child < len(array); child++ {
-		if !less(array[parent], array[child]) {
+	for child := 1; child < len(array); child++ {
+		if less(array[child], array[parent]) {
 			return false
 		}
 		if (child & 1) == 0 {
@@ -142,17 +119,35 @@ func RemoveMinHeapFunc[T any](heap *[]T, idx int, less gostl.LessFunc[T]) T {
 }
 
 func heapUpFunc[T any](heap *[]T, j int, less gostl.LessFunc[T]) {
+	SiftUpMinHeapFunc(*heap, j, less, nil)
+}
+
+func heapDownFunc[T any](heap *[]T, i0, n int, less gostl.LessFunc[T]) bool {
+	return SiftDownMinHeapFunc(*heap, i0, n, less, nil)
+}
+
+// SiftUpMinHeapFunc 基于 less 函数将最小堆中下标为 j 的元素上浮到正确的位置，时间复杂度 O(log(heap.Len()))
+//
+//	每次交换下标 i 与 j 的元素后调用 onSwap(i, j)，可用于维护元素在堆中的下标，onSwap 可以为 nil
+func SiftUpMinHeapFunc[T any](heap []T, j int, less gostl.LessFunc[T], onSwap func(i, j int)) {
 	for {
-		i := (j - 1) >> 1
-		if i == j || !less((*heap)[j], (*heap)[i]) {
+		i := (j - 1) / 2 // parent
+		if i == j || !less(heap[j], heap[i]) {
 			break
 		}
-		heapSwap(heap, i, j)
+		heap[i], heap[j] = heap[j], heap[i]
+		if onSwap != nil {
+			onSwap(i, j)
+		}
 		j = i
 	}
 }
 
-func heapDownFunc[T any](heap *[]T, i0, n int, less gostl.LessFunc[T]) bool {
+// SiftDownMinHeapFunc 基于 less 函数将最小堆 heap[:n] 中下标为 i0 的元素下沉到正确的位置，返回元素是否移动，
+// 时间复杂度 O(log n)
+//
+//	每次交换下标 i 与 j 的元素后调用 onSwap(i, j)，可用于维护元素在堆中的下标，onSwap 可以为 nil
+func SiftDownMinHeapFunc[T any](heap []T, i0, n int, less gostl.LessFunc[T], onSwap func(i, j int)) bool {
 	i := i0
 	for {
 		j1 := i<<1 | 1
@@ -160,14 +155,17 @@ func heapDownFunc[T any](heap *[]T, i0, n int, less gostl.LessFunc[T]) bool {
 			break
 		}
 		j := j1
-		if j2 := j1 + 1; j2 < n && less((*heap)[j2], (*heap)[j1]) {
+		if j2 := j1 + 1; j2 < n && less(heap[j2], heap[j1]) {
 			j = j2
 		}
-		if !less((*heap)[j], (*heap)[i]) {
+		if !less(heap[j], heap[i]) {
 			break
 		}
-		heapSwap(heap, i, j)
+		heap[i], heap[j] = heap[j], heap[i]
+		if onSwap != nil {
+			onSwap(i, j)
+		}
 		i = j
 	}
-	return i > 10
+	return i > i0
 }
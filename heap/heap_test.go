@@ -0,0 +1,418 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomInts(n int) []int {
+	rander := rand.New(rand.NewSource(int64(n)))
+	values := make([]int, n)
+	for i := range values {
+		values[i] = rander.Intn(n)
+	}
+	return values
+}
+
+func Test_BinaryHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		values := randomInts(n)
+		sorted := append([]int{}, values...)
+		sort.Ints(sorted)
+
+		minHeap, minHeapFunc := []int{}, []int{}
+		maxHeap, maxHeapFunc := []int{}, []int{}
+		for _, v := range values {
+			PushMinHeap(&minHeap, v)
+			PushMaxHeap(&maxHeap, v)
+			PushMaxHeapFunc(&maxHeapFunc, v, less)
+		}
+		minHeapFunc = append(minHeapFunc, values...)
+		NewMinHeapFunc(&minHeapFunc, less)
+		if !IsMinHeap(minHeap) || !IsMinHeapFunc(minHeapFunc, less) || !IsMaxHeap(maxHeap) || !IsMaxHeapFunc(maxHeapFunc, less) {
+			t.Fatalf("n = %d: heap property violated", n)
+		}
+		for i := range sorted {
+			if got := PopMinHeapFunc(&minHeapFunc, less); got != sorted[i] {
+				t.Fatalf("PopMinHeapFunc() = %d, want %d", got, sorted[i])
+			}
+			if got := PopMaxHeap(&maxHeap); got != sorted[n-1-i] {
+				t.Fatalf("PopMaxHeap() = %d, want %d", got, sorted[n-1-i])
+			}
+			if got := PopMaxHeapFunc(&maxHeapFunc, less); got != sorted[n-1-i] {
+				t.Fatalf("PopMaxHeapFunc() = %d, want %d", got, sorted[n-1-i])
+			}
+		}
+	}
+	if IsMinHeap([]int{1, 2, 0}) || IsMaxHeap([]int{2, 1, 3}) {
+		t.Fatal("IsMinHeap/IsMaxHeap accepted an invalid heap")
+	}
+}
+
+func Test_MinHeapRegression(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	// 第一个元素没有父节点，heapUp 不能访问下标 -1
+	var heap, heapFunc []int
+	PushMinHeap(&heap, 1)
+	PushMinHeapFunc(&heapFunc, 1, less)
+	if len(heap) != 1 || len(heapFunc) != 1 || heap[0] != 1 || heapFunc[0] != 1 {
+		t.Fatalf("PushMinHeap() on an empty slice = %v, %v", heap, heapFunc)
+	}
+
+	// 下标 i 的父节点为 (i-1)/2
+	for _, c := range []struct {
+		array []int
+		want  bool
+	}{
+		{nil, true},
+		{[]int{1}, true},
+		{[]int{1, 1, 1}, true},
+		{[]int{0, 5, 1, 6, 7, 2}, true},
+		{[]int{2, 1}, false},
+		{[]int{0, 5, 1, 6, 4}, false},
+		{[]int{0, 1, 5, 2, 3, 4}, false},
+	} {
+		if got := IsMinHeap(c.array); got != c.want {
+			t.Fatalf("IsMinHeap(%v) = %v, want %v", c.array, got, c.want)
+		}
+		if got := IsMinHeapFunc(c.array, less); got != c.want {
+			t.Fatalf("IsMinHeapFunc(%v) = %v, want %v", c.array, got, c.want)
+		}
+	}
+}
+
+func Test_RemoveMinHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	rander := rand.New(rand.NewSource(1))
+	heap, heapFunc := randomInts(200), randomInts(200)
+	NewMinHeap(&heap)
+	NewMinHeapFunc(&heapFunc, less)
+	for len(heap) > 0 {
+		// 被删除的元素需要上浮或下沉到正确的位置，RemoveMinHeap 依赖 heapDown 报告元素是否下沉
+		idx := rander.Intn(len(heap))
+		want := heap[idx]
+		if got := RemoveMinHeap(&heap, idx); got != want {
+			t.Fatalf("RemoveMinHeap(%d) = %d, want %d", idx, got, want)
+		}
+		want = heapFunc[idx]
+		if got := RemoveMinHeapFunc(&heapFunc, idx, less); got != want {
+			t.Fatalf("RemoveMinHeapFunc(%d) = %d, want %d", idx, got, want)
+		}
+		if !IsMinHeap(heap) || !IsMinHeapFunc(heapFunc, less) {
+			t.Fatalf("heap property violated after removing index %d", idx)
+		}
+	}
+}
+
+func Test_DaryMinHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, d := range []int{2, 3, 4, 8} {
+		values := randomInts(300)
+		sorted := append([]int{}, values...)
+		sort.Ints(sorted)
+
+		h := append([]int{}, values[:150]...)
+		NewDaryMinHeap(&h, d)
+		hf := []int{}
+		for i, v := range values {
+			if i >= 150 {
+				PushDaryMinHeap(&h, v, d)
+			}
+			PushDaryMinHeapFunc(&hf, v, d, less)
+		}
+		if !IsDaryMinHeap(h, d) || !IsDaryMinHeapFunc(hf, d, less) {
+			t.Fatalf("d = %d: heap property violated", d)
+		}
+		RemoveDaryMinHeap(&h, 17, d)
+		PushDaryMinHeap(&h, -1, d)
+		if !IsDaryMinHeap(h, d) || PopDaryMinHeap(&h, d) != -1 {
+			t.Fatalf("d = %d: RemoveDaryMinHeap broke the heap", d)
+		}
+		for i := range sorted {
+			if got := PopDaryMinHeapFunc(&hf, d, less); got != sorted[i] {
+				t.Fatalf("d = %d: PopDaryMinHeapFunc() = %d, want %d", d, got, sorted[i])
+			}
+		}
+	}
+}
+
+func Test_MeldableHeap(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	ph, ph2 := NewPairingHeap[int](), NewPairingHeapFunc[int](func(a, b int) bool { return a < b })
+	fh, fh2 := NewFibonacciHeap[int](), NewFibonacciHeapFunc[int](func(a, b int) bool { return a < b })
+	phHandles := []*PairingHeapHandle[int]{}
+	fhHandles := []*FibonacciHeapHandle[int]{}
+	alive := map[int]int{} // 元素值均不相同，值 -> 句柄下标
+	for i, v := range rander.Perm(1000) {
+		alive[v] = i
+		if i%2 == 0 {
+			phHandles = append(phHandles, ph.Push(v))
+			fhHandles = append(fhHandles, fh.Push(v))
+		} else {
+			phHandles = append(phHandles, ph2.Push(v))
+			fhHandles = append(fhHandles, fh2.Push(v))
+		}
+	}
+	pop := func() int {
+		a, b := ph.Pop(), fh.Pop()
+		if a != b {
+			t.Fatalf("Pop() = %d, %d", a, b)
+		}
+		delete(alive, a)
+		return a
+	}
+
+	// 弹出部分元素，使斐波那契堆形成多层树
+	for i := 0; i < 10; i++ {
+		pop()
+	}
+	ph.Meld(ph2)
+	fh.Meld(fh2)
+	if ph2.Len() != 0 || fh2.Len() != 0 || ph.Len() != 990 || fh.Len() != 990 {
+		t.Fatal("Meld: wrong length")
+	}
+
+	for i := 0; i < 300; i++ {
+		idx := rander.Intn(len(phHandles))
+		if _, ok := alive[phHandles[idx].Value()]; !ok {
+			continue
+		}
+		delete(alive, phHandles[idx].Value())
+		v := -1 - i
+		alive[v] = idx
+		ph.DecreaseKey(phHandles[idx], v)
+		fh.DecreaseKey(fhHandles[idx], v)
+		if i%3 == 0 {
+			pop()
+		}
+	}
+
+	prev := ph.Top()
+	for !ph.Empty() {
+		v := pop()
+		if v < prev {
+			t.Fatalf("Pop() = %d after %d", v, prev)
+		}
+		prev = v
+	}
+	if !fh.Empty() || len(alive) != 0 {
+		t.Fatal("heaps are not drained")
+	}
+}
+
+func Test_MinMaxHeap(t *testing.T) {
+	values := randomInts(500)
+	h := append([]int{}, values[:200]...)
+	NewMinMaxHeap(&h)
+	for _, v := range values[200:] {
+		PushMinMaxHeap(&h, v)
+	}
+	if !IsMinMaxHeap(h) {
+		t.Fatal("heap property violated")
+	}
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	lo, hi := 0, len(sorted)-1
+	for i := 0; lo <= hi; i++ {
+		if i%3 == 0 {
+			if got := PopMinMaxHeapMax(&h); got != sorted[hi] {
+				t.Fatalf("PopMinMaxHeapMax() = %d, want %d", got, sorted[hi])
+			}
+			hi--
+		} else {
+			if got := PopMinMaxHeapMin(&h); got != sorted[lo] {
+				t.Fatalf("PopMinMaxHeapMin() = %d, want %d", got, sorted[lo])
+			}
+			lo++
+		}
+		if !IsMinMaxHeap(h) {
+			t.Fatal("heap property violated after pop")
+		}
+	}
+	if IsMinMaxHeap([]int{1, 3, 2, 4}) || !IsMinMaxHeap([]int{1, 3, 2, 2}) {
+		t.Fatal("IsMinMaxHeap: wrong result")
+	}
+
+	// 空堆上弹出应给出明确的 panic 信息，而不是下标越界
+	for want, pop := range map[string]func(){
+		"heap.PopMinMaxHeapMin: empty heap": func() { PopMinMaxHeapMin(&h) },
+		"heap.PopMinMaxHeapMax: empty heap": func() { PopMinMaxHeapMax(&h) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Fatalf("recover() = %v, want %q", r, want)
+				}
+			}()
+			pop()
+		}()
+	}
+}
+
+func Test_HeapSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, n := range []int{0, 1, 2, 10, 257} {
+		values := randomInts(n)
+		sorted := append([]int{}, values...)
+		sort.Ints(sorted)
+
+		a, b := append([]int{}, values...), append([]int{}, values...)
+		HeapSort(a)
+		HeapSortFunc(b, less)
+		for i := range sorted {
+			if a[i] != sorted[i] || b[i] != sorted[i] {
+				t.Fatalf("n = %d: HeapSort mismatch at %d", n, i)
+			}
+		}
+
+		for _, k := range []int{0, 1, n / 2, n} {
+			if k > n {
+				continue
+			}
+			a, b = append([]int{}, values...), append([]int{}, values...)
+			PartialSort(a, k)
+			PartialSortFunc(b, k, less)
+			for i := 0; i < k; i++ {
+				if a[i] != sorted[i] || b[i] != sorted[i] {
+					t.Fatalf("n = %d, k = %d: PartialSort mismatch at %d", n, k, i)
+				}
+			}
+			if k == n {
+				continue
+			}
+			a, b = append([]int{}, values...), append([]int{}, values...)
+			NthElement(a, k)
+			NthElementFunc(b, k, less)
+			if a[k] != sorted[k] || b[k] != sorted[k] {
+				t.Fatalf("n = %d, k = %d: NthElement = %d, want %d", n, k, a[k], sorted[k])
+			}
+			for i := range a {
+				if i < k && a[i] > a[k] || i > k && a[i] < a[k] {
+					t.Fatalf("n = %d, k = %d: NthElement is not partitioned", n, k)
+				}
+			}
+		}
+	}
+}
+
+func Test_TopK(t *testing.T) {
+	values := randomInts(1000)
+	topK := NewTopK[int](10)
+	topKFunc := NewTopKFunc[int](10, func(a, b int) bool { return a > b })
+	for _, v := range values {
+		topK.Push(v)
+		topKFunc.Push(v)
+	}
+	sort.Ints(values)
+	got, gotFunc := topK.Values(), topKFunc.Values()
+	if len(got) != 10 || topK.Min() != values[990] {
+		t.Fatalf("TopK: Len() = %d, Min() = %d", len(got), topK.Min())
+	}
+	for i := range got {
+		if got[i] != values[999-i] || gotFunc[i] != values[i] {
+			t.Fatalf("TopK: Values()[%d] = %d, %d", i, got[i], gotFunc[i])
+		}
+	}
+}
+
+func Test_MergeK(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	var slices [][]int
+	var all []int
+	for i := 0; i < 7; i++ {
+		values := randomInts(i * 13)
+		sort.Ints(values)
+		slices = append(slices, values)
+		all = append(all, values...)
+	}
+	sort.Ints(all)
+
+	got := MergeKSlices(less, slices...).Collect()
+	if len(got) != len(all) {
+		t.Fatalf("MergeKSlices: len = %d, want %d", len(got), len(all))
+	}
+	for i := range all {
+		if got[i] != all[i] {
+			t.Fatalf("MergeKSlices: [%d] = %d, want %d", i, got[i], all[i])
+		}
+	}
+
+	sources := make([]Source[int], len(slices))
+	for i, slice := range slices {
+		sources[i] = SliceSource(slice)
+	}
+	distinct := MergeKDistinct(less, sources...).Collect()
+	for i := 1; i < len(distinct); i++ {
+		if distinct[i-1] >= distinct[i] {
+			t.Fatalf("MergeKDistinct: %d before %d", distinct[i-1], distinct[i])
+		}
+	}
+	if len(distinct) == 0 || distinct[0] != all[0] || distinct[len(distinct)-1] != all[len(all)-1] {
+		t.Fatalf("MergeKDistinct: unexpected range %v", distinct)
+	}
+
+	if _, ok := MergeK[int](less).Next(); ok {
+		t.Fatalf("MergeK: Next() of no sources should fail")
+	}
+}
+
+func Test_RadixHeap(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	h := NewRadixHeap[uint32, int]()
+	var ref []uint32
+	for i := 0; i < 10000; i++ {
+		if len(ref) == 0 || rander.Intn(3) > 0 {
+			key := h.Last() + uint32(rander.Intn(1000))
+			h.Push(key, int(key)*2)
+			PushMinHeap(&ref, key)
+			continue
+		}
+		if key, _ := h.Top(); key != ref[0] {
+			t.Fatalf("RadixHeap.Top() = %d, want %d", key, ref[0])
+		}
+		key, value := h.Pop()
+		if want := PopMinHeap(&ref); key != want || value != int(want)*2 {
+			t.Fatalf("RadixHeap.Pop() = (%d, %d), want %d", key, value, want)
+		}
+	}
+	if h.Len() != len(ref) {
+		t.Fatalf("RadixHeap.Len() = %d, want %d", h.Len(), len(ref))
+	}
+	for !h.Empty() {
+		key, _ := h.Pop()
+		if want := PopMinHeap(&ref); key != want {
+			t.Fatalf("RadixHeap.Pop() = %d, want %d", key, want)
+		}
+	}
+
+	// Top 不能改变 Last，否则介于 Last 与最小键之间的合法 Push 会 panic
+	h = NewRadixHeap[uint32, int]()
+	h.Push(5, 0)
+	h.Pop()
+	h.Push(10, 0)
+	if key, _ := h.Top(); key != 10 || h.Last() != 5 {
+		t.Fatalf("RadixHeap.Top() = %d, Last() = %d, want 10, 5", key, h.Last())
+	}
+	h.Push(7, 0)
+	if key, _ := h.Pop(); key != 7 {
+		t.Fatalf("RadixHeap.Pop() = %d, want 7", key)
+	}
+
+	// 键重复时 Top 的缓存必须与 Pop 弹出的元素一致，包括 Top 之后又 Push 的情况
+	h = NewRadixHeap[uint32, int]()
+	for i := 0; i < 10000; i++ {
+		if h.Empty() || rander.Intn(3) > 0 {
+			h.Push(h.Last()+uint32(rander.Intn(8)), i)
+			if rander.Intn(2) == 0 {
+				h.Top()
+			}
+			continue
+		}
+		topKey, topValue := h.Top()
+		if key, value := h.Pop(); key != topKey || value != topValue {
+			t.Fatalf("RadixHeap.Pop() = (%d, %d), Top() = (%d, %d)", key, value, topKey, topValue)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package heap
+
+import "gostl"
+
+// NewMaxHeap 将 array 数组构建为最大堆，时间复杂度 O(array.Len())
+func NewMaxHeap[T gostl.Ordered](array *[]T) {
+	n := len(*array)
+	for i := (n >> 1) - 1; i >= 0; i-- {
+		maxHeapDown(array, i, n)
+	}
+}
+
+// IsMaxHeap 判断 array 数组是否为最大堆，时间复杂度 O(array.Len())
+func IsMaxHeap[T gostl.Ordered](array []T) bool {
+	parent := 0
+	for child := 1; child < len(array); child++ {
+		if array[parent] < array[child] {
+			return false
+		}
+		if (child & 1) == 0 {
+			parent++
+		}
+	}
+	return true
+}
+
+// PushMaxHeap 将元素插入到最大堆，时间复杂度 O(log(heap.Len()))
+func PushMaxHeap[T gostl.Ordered](heap *[]T, value T) {
+	*heap = append(*heap, value)
+	maxHeapUp(heap, len(*heap)-1)
+}
+
+// PopMaxHeap 删除并返回最大堆的根元素，时间复杂度 O(log(heap.Len()))
+func PopMaxHeap[T gostl.Ordered](heap *[]T) T {
+	h := *heap
+	n := len(h) - 1
+	heapSwap(&h, 0, n)
+	maxHeapDown(&h, 0, n)
+	*heap = h[0:n]
+	return h[n]
+}
+
+// RemoveMaxHeap 删除并返回最大堆中的指定元素，时间复杂度 O(log(heap.Len()))
+func RemoveMaxHeap[T gostl.Ordered](heap *[]T, idx int) T {
+	h := *heap
+	n := len(h) - 1
+	if n != idx {
+		heapSwap(&h, idx, n)
+		if !maxHeapDown(&h, idx, n) {
+			maxHeapUp(&h, idx)
+		}
+	}
+	*heap = h[0:n]
+	return h[n]
+}
+
+func maxHeapUp[T gostl.Ordered](heap *[]T, j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !((*heap)[i] < (*heap)[j]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		j = i
+	}
+}
+
+func maxHeapDown[T gostl.Ordered](heap *[]T, i0, n int) bool {
+	i := i0
+	for {
+		j1 := i<<1 | 1
+		if j1 >= n || j1 < 0 {
+			break
+		}
+		j := j1
+		if j2 := j1 + 1; j2 < n && (*heap)[j1] < (*heap)[j2] {
+			j = j2
+		}
+		if !((*heap)[i] < (*heap)[j]) {
+			break
+		}
+		heapSwap(heap, i, j)
+		i = j
+	}
+	return i > i0
+}
+
+// NewMaxHeapFunc 基于 less 函数构建最大堆，时间复杂度 O(array.Len())
+func NewMaxHeapFunc[T any](array *[]T, less gostl.LessFunc[T]) {
+	NewMinHeapFunc(array, func(a, b T) bool { return less(b, a) })
+}
+
+// IsMaxHeapFunc 基于 less 函数判断 array 数组是否为最大堆，时间复杂度 O(array.Len())
+func IsMaxHeapFunc[T any](array []T, less gostl.LessFunc[T]) bool {
+	return IsMinHeapFunc(array, func(a, b T) bool { return less(b, a) })
+}
+
+// PushMaxHeapFunc 基于 less 函数将元素插入到最大堆，时间复杂度 O(log(heap.Len()))
+func PushMaxHeapFunc[T any](heap *[]T, value T, less gostl.LessFunc[T]) {
+	PushMinHeapFunc(heap, value, func(a, b T) bool { return less(b, a) })
+}
+
+// PopMaxHeapFunc 基于 less 函数删除并返回最大堆的根元素，时间复杂度 O(log(heap.Len()))
+func PopMaxHeapFunc[T any](heap *[]T, less gostl.LessFunc[T]) T {
+	return PopMinHeapFunc(heap, func(a, b T) bool { return less(b, a) })
+}
+
+// RemoveMaxHeapFunc 基于 less 函数删除并返回最大堆中的指定元素，时间复杂度 O(log(heap.Len()))
+func RemoveMaxHeapFunc[T any](heap *[]T, idx int, less gostl.LessFunc[T]) T {
+	return RemoveMinHeapFunc(heap, idx, func(a, b T) bool { return less(b, a) })
+}
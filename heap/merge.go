@@ -0,0 +1,129 @@
+package heap
+
+import "gostl"
+
+// Source 有序数据源，每次调用返回下一个元素，ok 为 false 表示数据源已耗尽
+type Source[T any] func() (value T, ok bool)
+
+// SliceSource 将有序切片包装为数据源
+func SliceSource[T any](slice []T) Source[T] {
+	i := 0
+	return func() (T, bool) {
+		if i >= len(slice) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return slice[i-1], true
+	}
+}
+
+// Merger 基于最小堆的 K 路归并器，惰性地从多个有序数据源中按顺序产出元素，
+// 相等的元素按数据源的顺序产出，每次 Next 的时间复杂度 O(log k)
+type Merger[T any] struct {
+	heap     []mergeItem[T]
+	sources  []Source[T]
+	less     gostl.LessFunc[T]
+	distinct bool
+	last     T
+	hasLast  bool
+}
+
+type mergeItem[T any] struct {
+	value  T
+	source int // 元素所属数据源的下标
+}
+
+// MergeK 基于比较函数 less 构造一个归并 sources 的 Merger，每个数据源都必须按 less 升序排列
+func MergeK[T any](less gostl.LessFunc[T], sources ...Source[T]) *Merger[T] {
+	m := &Merger[T]{
+		heap:    make([]mergeItem[T], 0, len(sources)),
+		sources: sources,
+		less:    less,
+	}
+	for i, source := range sources {
+		if value, ok := source(); ok {
+			m.heap = append(m.heap, mergeItem[T]{value, i})
+		}
+	}
+	NewMinHeapFunc(&m.heap, m.itemLess)
+	return m
+}
+
+// MergeKSlices 基于比较函数 less 构造一个归并 slices 的 Merger，每个切片都必须按 less 升序排列
+func MergeKSlices[T any](less gostl.LessFunc[T], slices ...[]T) *Merger[T] {
+	sources := make([]Source[T], len(slices))
+	for i, slice := range slices {
+		sources[i] = SliceSource(slice)
+	}
+	return MergeK(less, sources...)
+}
+
+// MergeKDistinct 与 MergeK 相同，但相等的元素只产出第一个（按数据源的顺序）
+func MergeKDistinct[T any](less gostl.LessFunc[T], sources ...Source[T]) *Merger[T] {
+	m := MergeK(less, sources...)
+	m.distinct = true
+	return m
+}
+
+// Next 返回下一个元素，ok 为 false 表示所有数据源均已耗尽
+func (m *Merger[T]) Next() (value T, ok bool) {
+	for len(m.heap) > 0 {
+		value = m.pop()
+		if m.distinct {
+			if m.hasLast && !m.less(m.last, value) {
+				continue
+			}
+			m.last, m.hasLast = value, true
+		}
+		return value, true
+	}
+	return value, false
+}
+
+// ForEach 依次为剩余的每个元素执行 f 函数
+func (m *Merger[T]) ForEach(f func(value T)) {
+	for value, ok := m.Next(); ok; value, ok = m.Next() {
+		f(value)
+	}
+}
+
+// ForEachIf 依次为剩余的每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (m *Merger[T]) ForEachIf(f func(value T) bool) {
+	for value, ok := m.Next(); ok; value, ok = m.Next() {
+		if !f(value) {
+			return
+		}
+	}
+}
+
+// Collect 返回剩余的所有元素
+func (m *Merger[T]) Collect() []T {
+	var values []T
+	m.ForEach(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
+
+// pop 弹出堆顶元素，并用其数据源的下一个元素补位
+func (m *Merger[T]) pop() T {
+	top := m.heap[0]
+	if value, ok := m.sources[top.source](); ok {
+		m.heap[0].value = value
+		heapDownFunc(&m.heap, 0, len(m.heap), m.itemLess)
+	} else {
+		PopMinHeapFunc(&m.heap, m.itemLess)
+	}
+	return top.value
+}
+
+func (m *Merger[T]) itemLess(a, b mergeItem[T]) bool {
+	if m.less(a.value, b.value) {
+		return true
+	}
+	if m.less(b.value, a.value) {
+		return false
+	}
+	return a.source < b.source
+}
@@ -0,0 +1,198 @@
+package heap
+
+import (
+	"gostl"
+	"math/bits"
+)
+
+// 最小最大堆：偶数层（根节点为第 0 层）的节点不大于其所有子孙，奇数层的节点不小于其所有子孙，
+// 最小元素位于根节点，最大元素位于根节点的两个子节点之一
+
+// NewMinMaxHeap 将 array 数组构建为最小最大堆，时间复杂度 O(array.Len())
+func NewMinMaxHeap[T gostl.Ordered](array *[]T) {
+	NewMinMaxHeapFunc(array, lessOrdered[T])
+}
+
+// IsMinMaxHeap 判断 array 数组是否为最小最大堆，时间复杂度 O(array.Len())
+func IsMinMaxHeap[T gostl.Ordered](array []T) bool {
+	return IsMinMaxHeapFunc(array, lessOrdered[T])
+}
+
+// PushMinMaxHeap 将元素插入到最小最大堆，时间复杂度 O(log(heap.Len()))
+func PushMinMaxHeap[T gostl.Ordered](heap *[]T, value T) {
+	PushMinMaxHeapFunc(heap, value, lessOrdered[T])
+}
+
+// PopMinMaxHeapMin 删除并返回最小最大堆中的最小元素，若堆为空则 panic，时间复杂度 O(log(heap.Len()))
+func PopMinMaxHeapMin[T gostl.Ordered](heap *[]T) T {
+	return PopMinMaxHeapMinFunc(heap, lessOrdered[T])
+}
+
+// PopMinMaxHeapMax 删除并返回最小最大堆中的最大元素，若堆为空则 panic，时间复杂度 O(log(heap.Len()))
+func PopMinMaxHeapMax[T gostl.Ordered](heap *[]T) T {
+	return PopMinMaxHeapMaxFunc(heap, lessOrdered[T])
+}
+
+// MinMaxHeapMaxIndex 返回最小最大堆中最大元素的下标，若堆为空则返回 -1，时间复杂度 O(1)
+func MinMaxHeapMaxIndex[T gostl.Ordered](heap []T) int {
+	return MinMaxHeapMaxIndexFunc(heap, lessOrdered[T])
+}
+
+func lessOrdered[T gostl.Ordered](a, b T) bool {
+	return a < b
+}
+
+// NewMinMaxHeapFunc 基于 less 函数将 array 数组构建为最小最大堆，时间复杂度 O(array.Len())
+func NewMinMaxHeapFunc[T any](array *[]T, less gostl.LessFunc[T]) {
+	n := len(*array)
+	for i := (n >> 1) - 1; i >= 0; i-- {
+		minMaxHeapDown(*array, i, n, less)
+	}
+}
+
+// IsMinMaxHeapFunc 基于 less 函数判断 array 数组是否为最小最大堆，时间复杂度 O(array.Len())
+func IsMinMaxHeapFunc[T any](array []T, less gostl.LessFunc[T]) bool {
+	// ordered 判断 array[i] 与其祖先 array[anc] 是否满足最小最大堆的性质
+	ordered := func(anc, i int) bool {
+		if isMinLevel(anc) {
+			return !less(array[i], array[anc])
+		}
+		return !less(array[anc], array[i])
+	}
+	for i := 1; i < len(array); i++ {
+		p := (i - 1) / 2
+		if !ordered(p, i) || p > 0 && !ordered((p-1)/2, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// PushMinMaxHeapFunc 基于 less 函数将元素插入到最小最大堆，时间复杂度 O(log(heap.Len()))
+func PushMinMaxHeapFunc[T any](heap *[]T, value T, less gostl.LessFunc[T]) {
+	*heap = append(*heap, value)
+	minMaxHeapUp(*heap, len(*heap)-1, less)
+}
+
+// PopMinMaxHeapMinFunc 基于 less 函数删除并返回最小最大堆中的最小元素，若堆为空则 panic，时间复杂度 O(log(heap.Len()))
+func PopMinMaxHeapMinFunc[T any](heap *[]T, less gostl.LessFunc[T]) T {
+	if len(*heap) == 0 {
+		panic("heap.PopMinMaxHeapMin: empty heap")
+	}
+	return minMaxHeapRemove(heap, 0, less)
+}
+
+// PopMinMaxHeapMaxFunc 基于 less 函数删除并返回最小最大堆中的最大元素，若堆为空则 panic，时间复杂度 O(log(heap.Len()))
+func PopMinMaxHeapMaxFunc[T any](heap *[]T, less gostl.LessFunc[T]) T {
+	if len(*heap) == 0 {
+		panic("heap.PopMinMaxHeapMax: empty heap")
+	}
+	return minMaxHeapRemove(heap, MinMaxHeapMaxIndexFunc(*heap, less), less)
+}
+
+// MinMaxHeapMaxIndexFunc 基于 less 函数返回最小最大堆中最大元素的下标，若堆为空则返回 -1，时间复杂度 O(1)
+func MinMaxHeapMaxIndexFunc[T any](heap []T, less gostl.LessFunc[T]) int {
+	switch len(heap) {
+	case 0:
+		return -1
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if less(heap[1], heap[2]) {
+		return 2
+	}
+	return 1
+}
+
+// isMinLevel 判断下标 i 是否位于最小层
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))&1 == 1
+}
+
+func minMaxHeapRemove[T any](heap *[]T, idx int, less gostl.LessFunc[T]) T {
+	h := *heap
+	n := len(h) - 1
+	heapSwap(&h, idx, n)
+	if idx < n {
+		minMaxHeapDown(h, idx, n, less)
+	}
+	*heap = h[0:n]
+	return h[n]
+}
+
+func minMaxHeapUp[T any](heap []T, i int, less gostl.LessFunc[T]) {
+	if i == 0 {
+		return
+	}
+	p := (i - 1) / 2
+	if isMinLevel(i) {
+		if less(heap[p], heap[i]) {
+			heapSwap(&heap, i, p)
+			minMaxHeapUpLevel(heap, p, func(a, b T) bool { return less(b, a) })
+		} else {
+			minMaxHeapUpLevel(heap, i, less)
+		}
+	} else {
+		if less(heap[i], heap[p]) {
+			heapSwap(&heap, i, p)
+			minMaxHeapUpLevel(heap, p, less)
+		} else {
+			minMaxHeapUpLevel(heap, i, func(a, b T) bool { return less(b, a) })
+		}
+	}
+}
+
+// minMaxHeapUpLevel 沿祖父节点向上调整，before(a, b) 表示 a 应位于 b 的祖先层
+func minMaxHeapUpLevel[T any](heap []T, i int, before gostl.LessFunc[T]) {
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2
+		if !before(heap[i], heap[g]) {
+			break
+		}
+		heapSwap(&heap, i, g)
+		i = g
+	}
+}
+
+func minMaxHeapDown[T any](heap []T, i, n int, less gostl.LessFunc[T]) {
+	if isMinLevel(i) {
+		minMaxHeapDownLevel(heap, i, n, less)
+	} else {
+		minMaxHeapDownLevel(heap, i, n, func(a, b T) bool { return less(b, a) })
+	}
+}
+
+// minMaxHeapDownLevel 沿子孙节点向下调整，before(a, b) 表示 a 应位于 b 的祖先层
+func minMaxHeapDownLevel[T any](heap []T, i, n int, before gostl.LessFunc[T]) {
+	for {
+		c1 := i<<1 | 1
+		if c1 >= n {
+			return
+		}
+
+		// 在子节点与孙节点中找到最应该上移的节点 m
+		m := c1
+		if c1+1 < n && before(heap[c1+1], heap[m]) {
+			m = c1 + 1
+		}
+		for g, end := c1<<1|1, min(c1<<1+5, n); g < end; g++ {
+			if before(heap[g], heap[m]) {
+				m = g
+			}
+		}
+
+		if !before(heap[m], heap[i]) {
+			return
+		}
+		heapSwap(&heap, i, m)
+		if m <= c1+1 { // m 为子节点
+			return
+		}
+		if p := (m - 1) / 2; before(heap[p], heap[m]) {
+			heapSwap(&heap, m, p)
+		}
+		i = m
+	}
+}
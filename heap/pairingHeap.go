@@ -0,0 +1,191 @@
+package heap
+
+import "gostl"
+
+// PairingHeapHandle 配对堆中元素的句柄，由 Push 返回，用于 DecreaseKey
+type PairingHeapHandle[T any] struct {
+	child   *PairingHeapHandle[T] // 最左侧的子节点
+	sibling *PairingHeapHandle[T] // 右侧的兄弟节点
+	prev    *PairingHeapHandle[T] // 最左侧的子节点指向父节点，其余节点指向左侧的兄弟节点
+	value   T
+}
+
+// Value 返回句柄对应的元素
+func (h *PairingHeapHandle[T]) Value() T {
+	return h.value
+}
+
+// PairingHeap 配对堆（最小堆），Push、Top、Meld 的时间复杂度为 O(1)，Pop 的均摊时间复杂度为 O(log n)
+type PairingHeap[T any] struct {
+	root *PairingHeapHandle[T]
+	size int
+	impl phImpl[T]
+}
+
+// NewPairingHeap 构造一个可比较类型的配对堆
+func NewPairingHeap[T gostl.Ordered]() *PairingHeap[T] {
+	h := phOrdered[T]{}
+	h.impl = (phImpl[T])(&h)
+	return &h.PairingHeap
+}
+
+// NewPairingHeapFunc 基于比较函数 less 构造一个配对堆
+func NewPairingHeapFunc[T any](less gostl.LessFunc[T]) *PairingHeap[T] {
+	h := phFunc[T]{}
+	h.lessFunc = less
+	h.impl = (phImpl[T])(&h)
+	return &h.PairingHeap
+}
+
+// Len 获取配对堆中元素的数量
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Empty 判断配对堆是否为空
+func (h *PairingHeap[T]) Empty() bool {
+	return h.size == 0
+}
+
+// Clear 清空配对堆，所有句柄均失效
+func (h *PairingHeap[T]) Clear() {
+	h.root = nil
+	h.size = 0
+}
+
+// Top 获取配对堆的最小元素，若配对堆为空则 panic
+func (h *PairingHeap[T]) Top() T {
+	if h.root == nil {
+		panic("PairingHeap.Top: empty heap")
+	}
+	return h.root.value
+}
+
+// Push 向配对堆插入元素，并返回该元素的句柄，时间复杂度 O(1)
+func (h *PairingHeap[T]) Push(value T) *PairingHeapHandle[T] {
+	node := &PairingHeapHandle[T]{value: value}
+	h.root = h.link(h.root, node)
+	h.size++
+	return node
+}
+
+// Pop 删除并返回配对堆的最小元素，若配对堆为空则 panic，均摊时间复杂度 O(log(h.Len()))
+func (h *PairingHeap[T]) Pop() T {
+	if h.root == nil {
+		panic("PairingHeap.Pop: empty heap")
+	}
+	root := h.root
+	h.root = h.mergePairs(root.child)
+	root.child = nil
+	h.size--
+	return root.value
+}
+
+// DecreaseKey 将句柄对应的元素修改为更小的 value，若 value 大于原值则 panic，均摊时间复杂度 O(log(h.Len()))
+//
+//	句柄必须属于当前配对堆（或已被 Meld 进当前配对堆）且尚未被弹出
+func (h *PairingHeap[T]) DecreaseKey(node *PairingHeapHandle[T], value T) {
+	if h.impl.less(node.value, value) {
+		panic("PairingHeap.DecreaseKey: new value is greater than the current value")
+	}
+	node.value = value
+	if node == h.root {
+		return
+	}
+
+	// 将以 node 为根的子树从原位置剪下，再与根节点合并
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.sibling = nil
+	node.prev = nil
+	h.root = h.link(h.root, node)
+}
+
+// Meld 将 other 中的所有元素合并到 h 中并清空 other，other 中的句柄在 h 中仍然有效，时间复杂度 O(1)
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if h == other {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
+
+// link 合并两棵配对树，较大的根成为较小的根的最左侧子节点
+func (h *PairingHeap[T]) link(a, b *PairingHeapHandle[T]) *PairingHeapHandle[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.impl.less(b.value, a.value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs 以两趟合并的方式合并兄弟链表 first 中的所有配对树
+func (h *PairingHeap[T]) mergePairs(first *PairingHeapHandle[T]) *PairingHeapHandle[T] {
+	if first == nil {
+		return nil
+	}
+
+	// 第一趟：从左到右两两合并
+	pairs := make([]*PairingHeapHandle[T], 0, 8)
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.sibling, b.prev = nil, nil
+		}
+		a.sibling, a.prev = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	// 第二趟：从右到左依次合并
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	root.prev = nil
+	root.sibling = nil
+	return root
+}
+
+type phImpl[T any] interface {
+	less(a, b T) bool
+}
+
+type phOrdered[T gostl.Ordered] struct {
+	PairingHeap[T]
+}
+
+func (h *phOrdered[T]) less(a, b T) bool {
+	return a < b
+}
+
+type phFunc[T any] struct {
+	PairingHeap[T]
+	lessFunc gostl.LessFunc[T]
+}
+
+func (h *phFunc[T]) less(a, b T) bool {
+	return h.lessFunc(a, b)
+}
@@ -0,0 +1,138 @@
+package heap
+
+import (
+	"gostl"
+	"math/bits"
+)
+
+// RadixHeap 基数堆，适用于单调优先队列（弹出的键单调不减，例如最短路与事件模拟中的时间戳），
+// 只比较键的二进制位，Push 的时间复杂度 O(1)，Pop 的均摊时间复杂度 O(log C)，C 为键的取值范围
+type RadixHeap[K gostl.Unsigned, V any] struct {
+	buckets [65][]radixHeapItem[K, V] // buckets[i] 中的键与 last 的最高不同位为第 i-1 位，buckets[0] 中的键等于 last
+	last    K                         // 最后一次弹出的键，新插入的键不能小于它
+	size    int
+
+	// Top 缓存的最小元素位置 buckets[topBucket][topIndex]，即下一次 Pop 将弹出的元素，Pop 与 Clear 后失效
+	topCached           bool
+	topBucket, topIndex int
+}
+
+type radixHeapItem[K gostl.Unsigned, V any] struct {
+	key   K
+	value V
+}
+
+// NewRadixHeap 构造一个空的基数堆
+func NewRadixHeap[K gostl.Unsigned, V any]() *RadixHeap[K, V] {
+	return &RadixHeap[K, V]{}
+}
+
+// Len 获取基数堆中元素的数量
+func (h *RadixHeap[K, V]) Len() int {
+	return h.size
+}
+
+// Empty 判断基数堆是否为空
+func (h *RadixHeap[K, V]) Empty() bool {
+	return h.size == 0
+}
+
+// Clear 清空基数堆，并将下界重置为 0
+func (h *RadixHeap[K, V]) Clear() {
+	for i := range h.buckets {
+		gostl.FillZero(h.buckets[i])
+		h.buckets[i] = h.buckets[i][:0]
+	}
+	h.last = 0
+	h.size = 0
+	h.topCached = false
+}
+
+// Last 返回最后一次弹出的键，即之后插入的键的下界
+func (h *RadixHeap[K, V]) Last() K {
+	return h.last
+}
+
+// Push 插入键为 key 的元素，若 key 小于最后一次弹出的键则 panic，时间复杂度 O(1)
+func (h *RadixHeap[K, V]) Push(key K, value V) {
+	if key < h.last {
+		panic("RadixHeap.Push: key is less than the last popped key")
+	}
+	i := h.bucketIndex(key)
+	h.buckets[i] = append(h.buckets[i], radixHeapItem[K, V]{key, value})
+	h.size++
+	// 键相同的元素位于同一个桶中，Pop 弹出其中最后插入的一个
+	if h.topCached && key <= h.buckets[h.topBucket][h.topIndex].key {
+		h.topBucket, h.topIndex = i, len(h.buckets[i])-1
+	}
+}
+
+// Top 获取键最小的元素（即 Pop 将弹出的元素），不修改基数堆，若基数堆为空则 panic，
+// 均摊时间复杂度 O(1)：buckets[0] 为空时需要扫描一次最低的非空桶，结果缓存到下一次 Pop，扫描的代价由随后 Pop 的重新分配均摊
+func (h *RadixHeap[K, V]) Top() (K, V) {
+	if h.size == 0 {
+		panic("RadixHeap.Top: empty heap")
+	}
+	if b := h.buckets[0]; len(b) > 0 { // buckets[0] 中的键都等于 last，Pop 直接弹出最后一个
+		return b[len(b)-1].key, b[len(b)-1].value
+	}
+	if !h.topCached {
+		i := 1
+		for len(h.buckets[i]) == 0 {
+			i++
+		}
+		// pull 重新分配后键最小的元素按原顺序进入 buckets[0]，Pop 弹出其中最后一个
+		b, idx := h.buckets[i], 0
+		for j := range b {
+			if b[j].key <= b[idx].key {
+				idx = j
+			}
+		}
+		h.topCached, h.topBucket, h.topIndex = true, i, idx
+	}
+	item := h.buckets[h.topBucket][h.topIndex]
+	return item.key, item.value
+}
+
+// Pop 删除并返回键最小的元素，若基数堆为空则 panic，均摊时间复杂度 O(log C)
+func (h *RadixHeap[K, V]) Pop() (K, V) {
+	h.topCached = false
+	h.pull()
+	b := h.buckets[0]
+	n := len(b) - 1
+	item := b[n]
+	b[n] = radixHeapItem[K, V]{}
+	h.buckets[0] = b[:n]
+	h.size--
+	return item.key, item.value
+}
+
+func (h *RadixHeap[K, V]) bucketIndex(key K) int {
+	return bits.Len64(uint64(key ^ h.last))
+}
+
+// pull 保证 buckets[0] 非空：找到第一个非空的桶，以其中的最小键作为新的 last，并将该桶中的元素重新分配到更低的桶中
+func (h *RadixHeap[K, V]) pull() {
+	if len(h.buckets[0]) > 0 {
+		return
+	}
+	if h.size == 0 {
+		panic("RadixHeap: empty heap")
+	}
+
+	i := 1
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	b := h.buckets[i]
+	h.last = b[0].key
+	for _, item := range b[1:] {
+		h.last = min(h.last, item.key)
+	}
+	for _, item := range b {
+		j := h.bucketIndex(item.key)
+		h.buckets[j] = append(h.buckets[j], item)
+	}
+	gostl.FillZero(b)
+	h.buckets[i] = b[:0]
+}
@@ -0,0 +1,92 @@
+package heap
+
+import "gostl"
+
+// HeapSort 将 array 数组按升序排序，时间复杂度 O(n log n)，空间复杂度 O(1)，不稳定
+func HeapSort[T gostl.Ordered](array []T) {
+	NewMaxHeap(&array)
+	for n := len(array) - 1; n > 0; n-- {
+		heapSwap(&array, 0, n)
+		maxHeapDown(&array, 0, n)
+	}
+}
+
+// PartialSort 重排 array 数组，使 array[:k] 为最小的 k 个元素且按升序排列，其余元素顺序不确定，时间复杂度 O(n log k)
+func PartialSort[T gostl.Ordered](array []T, k int) {
+	k = min(k, len(array))
+	if k <= 0 {
+		return
+	}
+	heap := array[:k]
+	NewMaxHeap(&heap)
+	for i := k; i < len(array); i++ {
+		if array[i] < heap[0] {
+			array[i], heap[0] = heap[0], array[i]
+			maxHeapDown(&heap, 0, k)
+		}
+	}
+	HeapSort(heap)
+}
+
+// NthElement 重排 array 数组，使 array[n] 为升序排序后位于下标 n 的元素，
+// 且 array[:n] 中的元素均不大于 array[n]，array[n+1:] 中的元素均不小于 array[n]，时间复杂度 O(len(array) log n)
+func NthElement[T gostl.Ordered](array []T, n int) {
+	if n < 0 || n >= len(array) {
+		return
+	}
+	heap := array[:n+1]
+	NewMaxHeap(&heap)
+	for i := n + 1; i < len(array); i++ {
+		if array[i] < heap[0] {
+			array[i], heap[0] = heap[0], array[i]
+			maxHeapDown(&heap, 0, n+1)
+		}
+	}
+	heapSwap(&heap, 0, n)
+}
+
+// HeapSortFunc 基于 less 函数将 array 数组按升序排序，时间复杂度 O(n log n)，空间复杂度 O(1)，不稳定
+func HeapSortFunc[T any](array []T, less gostl.LessFunc[T]) {
+	greater := func(a, b T) bool { return less(b, a) }
+	NewMinHeapFunc(&array, greater)
+	for n := len(array) - 1; n > 0; n-- {
+		heapSwap(&array, 0, n)
+		heapDownFunc(&array, 0, n, greater)
+	}
+}
+
+// PartialSortFunc 基于 less 函数重排 array 数组，使 array[:k] 为最小的 k 个元素且按升序排列，其余元素顺序不确定，时间复杂度 O(n log k)
+func PartialSortFunc[T any](array []T, k int, less gostl.LessFunc[T]) {
+	greater := func(a, b T) bool { return less(b, a) }
+	k = min(k, len(array))
+	if k <= 0 {
+		return
+	}
+	heap := array[:k]
+	NewMinHeapFunc(&heap, greater)
+	for i := k; i < len(array); i++ {
+		if less(array[i], heap[0]) {
+			array[i], heap[0] = heap[0], array[i]
+			heapDownFunc(&heap, 0, k, greater)
+		}
+	}
+	HeapSortFunc(heap, less)
+}
+
+// NthElementFunc 基于 less 函数重排 array 数组，使 array[n] 为升序排序后位于下标 n 的元素，
+// 且 array[:n] 中的元素均不大于 array[n]，array[n+1:] 中的元素均不小于 array[n]，时间复杂度 O(len(array) log n)
+func NthElementFunc[T any](array []T, n int, less gostl.LessFunc[T]) {
+	if n < 0 || n >= len(array) {
+		return
+	}
+	greater := func(a, b T) bool { return less(b, a) }
+	heap := array[:n+1]
+	NewMinHeapFunc(&heap, greater)
+	for i := n + 1; i < len(array); i++ {
+		if less(array[i], heap[0]) {
+			array[i], heap[0] = heap[0], array[i]
+			heapDownFunc(&heap, 0, n+1, greater)
+		}
+	}
+	heapSwap(&heap, 0, n)
+}
@@ -0,0 +1,117 @@
+package heap
+
+import "gostl"
+
+// TopK 流式 Top-K 累加器，保留已输入元素中最大的 k 个，内存占用为 O(k)
+type TopK[T any] struct {
+	heap []T // 大小不超过 k 的最小堆，堆顶为当前保留的最小元素
+	k    int
+	impl topKImpl[T]
+}
+
+// NewTopK 构造一个保留最大的 k 个元素的可比较类型累加器，k 小于 1 时 panic
+func NewTopK[T gostl.Ordered](k int) *TopK[T] {
+	if k < 1 {
+		panic("heap.NewTopK: k must be positive")
+	}
+	t := topKOrdered[T]{}
+	t.heap = make([]T, 0, k)
+	t.k = k
+	t.impl = (topKImpl[T])(&t)
+	return &t.TopK
+}
+
+// NewTopKFunc 基于比较函数 less 构造一个保留最大的 k 个元素的累加器，k 小于 1 时 panic
+func NewTopKFunc[T any](k int, less gostl.LessFunc[T]) *TopK[T] {
+	if k < 1 {
+		panic("heap.NewTopKFunc: k must be positive")
+	}
+	t := topKFunc[T]{}
+	t.heap = make([]T, 0, k)
+	t.k = k
+	t.less = less
+	t.impl = (topKImpl[T])(&t)
+	return &t.TopK
+}
+
+// K 返回累加器最多保留的元素数量
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Len 返回累加器当前保留的元素数量
+func (t *TopK[T]) Len() int {
+	return len(t.heap)
+}
+
+// Clear 清空累加器
+func (t *TopK[T]) Clear() {
+	gostl.FillZero(t.heap)
+	t.heap = t.heap[:0]
+}
+
+// Min 返回当前保留的元素中最小的一个，即进入 Top-K 的门槛，若累加器为空则 panic
+func (t *TopK[T]) Min() T {
+	return t.heap[0]
+}
+
+// Push 输入一个元素，返回该元素是否被保留，时间复杂度 O(log k)
+func (t *TopK[T]) Push(value T) bool {
+	return t.impl.Push(value)
+}
+
+// Values 返回当前保留的元素的拷贝，按降序排列，时间复杂度 O(k log k)
+func (t *TopK[T]) Values() []T {
+	values := make([]T, len(t.heap))
+	copy(values, t.heap)
+	t.impl.SortDesc(values)
+	return values
+}
+
+type topKImpl[T any] interface {
+	Push(value T) bool
+	SortDesc(values []T)
+}
+
+type topKOrdered[T gostl.Ordered] struct {
+	TopK[T]
+}
+
+func (t *topKOrdered[T]) Push(value T) bool {
+	if len(t.heap) < t.k {
+		PushMinHeap(&t.heap, value)
+		return true
+	}
+	if !(t.heap[0] < value) {
+		return false
+	}
+	t.heap[0] = value
+	heapDown(&t.heap, 0, t.k)
+	return true
+}
+
+func (t *topKOrdered[T]) SortDesc(values []T) {
+	HeapSortFunc(values, func(a, b T) bool { return b < a })
+}
+
+type topKFunc[T any] struct {
+	TopK[T]
+	less gostl.LessFunc[T]
+}
+
+func (t *topKFunc[T]) Push(value T) bool {
+	if len(t.heap) < t.k {
+		PushMinHeapFunc(&t.heap, value, t.less)
+		return true
+	}
+	if !t.less(t.heap[0], value) {
+		return false
+	}
+	t.heap[0] = value
+	heapDownFunc(&t.heap, 0, t.k, t.less)
+	return true
+}
+
+func (t *topKFunc[T]) SortDesc(values []T) {
+	HeapSortFunc(values, func(a, b T) bool { return t.less(b, a) })
+}
@@ -0,0 +1,76 @@
+package history
+
+import "testing"
+
+func Test_UndoStack(t *testing.T) {
+	var text []byte
+	h := NewUndoStack(
+		func(c byte) { text = append(text, c) },
+		func(c byte) { text = text[:len(text)-1] },
+		5,
+	)
+	expect := func(want string) {
+		t.Helper()
+		if string(text) != want {
+			t.Fatalf("text = %q, want %q", text, want)
+		}
+	}
+
+	start := h.Checkpoint()
+	h.Do('a')
+	h.Do('b')
+	afterB := h.Checkpoint()
+	h.Do('c')
+	h.Undo()
+	h.Undo()
+	expect("a")
+	h.Redo()
+	expect("ab")
+	h.Do('x') // 新操作清空重做栈
+	if h.Redo() || h.CanRedo() {
+		t.Fatalf("Redo() after Do() should fail")
+	}
+	expect("abx")
+
+	h.Begin()
+	h.Do('1')
+	h.Begin()
+	h.Do('2')
+	h.Commit()
+	h.Begin()
+	h.Do('3')
+	h.Rollback()
+	h.Commit()
+	expect("abx12")
+	h.Undo()
+	expect("abx")
+	h.Redo()
+	expect("abx12")
+
+	if err := h.RestoreTo(afterB); err != nil {
+		t.Fatalf("RestoreTo(afterB) = %v", err)
+	}
+	expect("ab")
+	if err := h.RestoreTo(start); err != nil {
+		t.Fatalf("RestoreTo(start) = %v", err)
+	}
+	expect("")
+	h.RestoreTo(afterB)
+	h.Redo()
+	h.Redo()
+	expect("abx12")
+
+	// 超出最大深度后最早的记录被丢弃
+	for _, c := range []byte("defg") {
+		h.Do(c)
+	}
+	if h.UndoLen() != 5 {
+		t.Fatalf("UndoLen() = %d, want 5", h.UndoLen())
+	}
+	if err := h.RestoreTo(start); err != ErrCheckpointNotFound {
+		t.Fatalf("RestoreTo(dropped) = %v", err)
+	}
+	for h.Undo() {
+	}
+	expect("abx")
+}
@@ -0,0 +1,216 @@
+package history
+
+import (
+	"errors"
+	"gostl/stack"
+)
+
+// ErrCheckpointNotFound 检查点已不在历史记录中（因超出最大深度被丢弃，或因撤销后执行了新操作而被清除）
+var ErrCheckpointNotFound = errors.New("history: checkpoint not found")
+
+// Checkpoint 历史记录中的一个状态，由 UndoStack.Checkpoint 返回
+type Checkpoint uint64
+
+// UndoStack 撤销/重做历史记录，T 为操作类型，通过 apply 执行操作、revert 撤销操作
+//
+//	Do 执行新操作时清空重做栈；事务内的多个操作作为一个整体撤销与重做，事务可以嵌套；
+//	历史记录超过最大深度时丢弃最早的记录，丢弃的均摊时间复杂度为 O(1)
+type UndoStack[T any] struct {
+	apply    func(op T)
+	revert   func(op T)
+	maxDepth int
+	undo     *stack.Stack[historyEntry[T]]
+	redo     *stack.Stack[historyEntry[T]]
+	txs      [][]T      // 进行中的事务，每层嵌套一个，内层在后
+	base     Checkpoint // 撤销栈为空时的状态
+	nextID   Checkpoint
+}
+
+// historyEntry 撤销栈与重做栈中的一条记录，id 为执行完该记录后的状态
+type historyEntry[T any] struct {
+	ops []T
+	id  Checkpoint
+}
+
+// NewUndoStack 构造一个空的历史记录，apply 执行操作，revert 撤销操作，
+// maxDepth 为可撤销记录的最大数量，不为正时不限制
+func NewUndoStack[T any](apply, revert func(op T), maxDepth int) *UndoStack[T] {
+	return &UndoStack[T]{
+		apply:    apply,
+		revert:   revert,
+		maxDepth: maxDepth,
+		undo:     stack.NewStack[historyEntry[T]](),
+		redo:     stack.NewStack[historyEntry[T]](),
+		nextID:   1,
+	}
+}
+
+// UndoLen 返回可撤销的记录数量
+func (h *UndoStack[T]) UndoLen() int {
+	return h.undo.Len()
+}
+
+// RedoLen 返回可重做的记录数量
+func (h *UndoStack[T]) RedoLen() int {
+	return h.redo.Len()
+}
+
+// CanUndo 返回是否有可撤销的记录
+func (h *UndoStack[T]) CanUndo() bool {
+	return !h.undo.Empty()
+}
+
+// CanRedo 返回是否有可重做的记录
+func (h *UndoStack[T]) CanRedo() bool {
+	return !h.redo.Empty()
+}
+
+// InTransaction 返回是否有进行中的事务
+func (h *UndoStack[T]) InTransaction() bool {
+	return len(h.txs) > 0
+}
+
+// Clear 清空历史记录，不会撤销任何操作，若有进行中的事务则 panic
+func (h *UndoStack[T]) Clear() {
+	h.mustNotInTransaction("Clear")
+	h.undo.Clear()
+	h.redo.Clear()
+	h.base = h.newID()
+}
+
+// Do 执行操作 op 并记录，同时清空重做栈；在事务中时 op 被加入当前事务
+func (h *UndoStack[T]) Do(op T) {
+	h.apply(op)
+	if len(h.txs) > 0 {
+		h.txs[len(h.txs)-1] = append(h.txs[len(h.txs)-1], op)
+		return
+	}
+	h.push([]T{op})
+}
+
+// Undo 撤销最近一条记录，若没有可撤销的记录则返回 false，若有进行中的事务则 panic
+func (h *UndoStack[T]) Undo() bool {
+	h.mustNotInTransaction("Undo")
+	if h.undo.Empty() {
+		return false
+	}
+	entry := h.undo.Pop()
+	for i := len(entry.ops) - 1; i >= 0; i-- {
+		h.revert(entry.ops[i])
+	}
+	h.redo.Push(entry)
+	return true
+}
+
+// Redo 重做最近一条被撤销的记录，若没有可重做的记录则返回 false，若有进行中的事务则 panic
+func (h *UndoStack[T]) Redo() bool {
+	h.mustNotInTransaction("Redo")
+	if h.redo.Empty() {
+		return false
+	}
+	entry := h.redo.Pop()
+	for _, op := range entry.ops {
+		h.apply(op)
+	}
+	h.undo.Push(entry)
+	return true
+}
+
+// Begin 开始一个事务，之后 Do 执行的操作在 Commit 时作为一条记录，事务可以嵌套
+func (h *UndoStack[T]) Begin() {
+	h.txs = append(h.txs, nil)
+}
+
+// Commit 提交最内层的事务：最外层事务的操作作为一条记录加入历史（空事务不产生记录），
+// 嵌套事务的操作并入外层事务，若没有进行中的事务则 panic
+func (h *UndoStack[T]) Commit() {
+	ops := h.popTransaction("Commit")
+	if len(h.txs) > 0 {
+		h.txs[len(h.txs)-1] = append(h.txs[len(h.txs)-1], ops...)
+		return
+	}
+	if len(ops) > 0 {
+		h.push(ops)
+	}
+}
+
+// Rollback 按相反顺序撤销最内层事务中的所有操作并丢弃该事务，若没有进行中的事务则 panic
+func (h *UndoStack[T]) Rollback() {
+	ops := h.popTransaction("Rollback")
+	for i := len(ops) - 1; i >= 0; i-- {
+		h.revert(ops[i])
+	}
+}
+
+// Checkpoint 返回当前状态的检查点，若有进行中的事务则 panic
+func (h *UndoStack[T]) Checkpoint() Checkpoint {
+	h.mustNotInTransaction("Checkpoint")
+	return h.current()
+}
+
+// RestoreTo 通过撤销或重做回到检查点 cp 对应的状态，
+// 若检查点已不在历史记录中则返回 ErrCheckpointNotFound 且不做任何修改，若有进行中的事务则 panic
+func (h *UndoStack[T]) RestoreTo(cp Checkpoint) error {
+	h.mustNotInTransaction("RestoreTo")
+	switch {
+	case h.contains(h.undo, cp) || cp == h.base:
+		for h.current() != cp {
+			h.Undo()
+		}
+	case h.contains(h.redo, cp):
+		for h.current() != cp {
+			h.Redo()
+		}
+	default:
+		return ErrCheckpointNotFound
+	}
+	return nil
+}
+
+// push 将一条新记录压入撤销栈，清空重做栈，并丢弃超出最大深度的最早记录
+func (h *UndoStack[T]) push(ops []T) {
+	h.undo.Push(historyEntry[T]{ops, h.newID()})
+	h.redo.Clear()
+	if h.maxDepth > 0 && h.undo.Len() > h.maxDepth { // 每次只压入一条记录，至多超出一条
+		h.base = h.undo.Bottom().id
+		h.undo.DropBottom(1)
+	}
+}
+
+// current 返回当前状态
+func (h *UndoStack[T]) current() Checkpoint {
+	if h.undo.Empty() {
+		return h.base
+	}
+	return h.undo.Top().id
+}
+
+func (h *UndoStack[T]) contains(stk *stack.Stack[historyEntry[T]], cp Checkpoint) bool {
+	found := false
+	stk.ForEachIf(func(entry *historyEntry[T]) bool {
+		found = entry.id == cp
+		return !found
+	})
+	return found
+}
+
+func (h *UndoStack[T]) newID() Checkpoint {
+	id := h.nextID
+	h.nextID++
+	return id
+}
+
+func (h *UndoStack[T]) popTransaction(method string) []T {
+	if len(h.txs) == 0 {
+		panic("UndoStack." + method + ": no transaction in progress")
+	}
+	ops := h.txs[len(h.txs)-1]
+	h.txs = h.txs[:len(h.txs)-1]
+	return ops
+}
+
+func (h *UndoStack[T]) mustNotInTransaction(method string) {
+	if len(h.txs) > 0 {
+		panic("UndoStack." + method + ": transaction in progress")
+	}
+}
@@ -0,0 +1,148 @@
+// Package containertest 为 gostl.OrderedSet 与 gostl.OrderedMap 的实现提供共用的测试流程
+package containertest
+
+import (
+	"gostl"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// CheckOrderedSet 对空的有序集合 s 执行固定种子的随机插入与删除，并与参照集合逐步比较，
+// 之后检查 ForEach 的顺序、Exist 与 LowerBound 的结果以及 Clear，s 会被清空
+func CheckOrderedSet(t *testing.T, name string, s gostl.OrderedSet[int]) {
+	t.Helper()
+	if s.Len() != 0 || s.Remove(0) || s.Exist(0) {
+		t.Fatalf("%s: not empty", name)
+	}
+	if got, ok := s.LowerBound(0); ok {
+		t.Fatalf("%s: LowerBound(0) = %d on an empty container", name, got)
+	}
+
+	rander := rand.New(rand.NewSource(1))
+	ref := map[int]bool{}
+	for i := 0; i < 5000; i++ {
+		key := rander.Intn(1000)
+		if rander.Intn(3) == 0 {
+			if s.Remove(key) != ref[key] {
+				t.Fatalf("%s: Remove(%d) != %v", name, key, ref[key])
+			}
+			delete(ref, key)
+		} else {
+			if s.TryInsert(key) == ref[key] {
+				t.Fatalf("%s: TryInsert(%d) == %v", name, key, ref[key])
+			}
+			ref[key] = true
+		}
+		if s.Len() != len(ref) {
+			t.Fatalf("%s: Len() = %d, want %d", name, s.Len(), len(ref))
+		}
+	}
+	CheckKeys(t, name, s, ref)
+
+	s.Clear()
+	CheckKeys(t, name+" after Clear", s, nil)
+}
+
+// CheckKeys 检查有序集合 s 恰好包含 want 中的键：Len、ForEach 的顺序，
+// 以及键的范围内（含两端之外各一个位置）每个位置的 Exist 与 LowerBound
+func CheckKeys(t *testing.T, name string, s gostl.OrderedSet[int], want map[int]bool) {
+	t.Helper()
+	keys := make([]int, 0, len(want))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	if s.Len() != len(keys) {
+		t.Fatalf("%s: Len() = %d, want %d", name, s.Len(), len(keys))
+	}
+	idx := 0
+	s.ForEach(func(key int) {
+		if idx >= len(keys) || key != keys[idx] {
+			t.Fatalf("%s: ForEach is not in ascending order", name)
+		}
+		idx++
+	})
+	if idx != len(keys) {
+		t.Fatalf("%s: ForEach visited %d keys, want %d", name, idx, len(keys))
+	}
+
+	lo, hi := 0, 0
+	if len(keys) > 0 {
+		lo, hi = keys[0], keys[len(keys)-1]
+	}
+	for i := lo - 1; i <= hi+1; i++ {
+		if s.Exist(i) != want[i] {
+			t.Fatalf("%s: Exist(%d) != %v", name, i, want[i])
+		}
+		j := sort.SearchInts(keys, i)
+		got, ok := s.LowerBound(i)
+		if ok != (j < len(keys)) || ok && got != keys[j] {
+			t.Fatalf("%s: LowerBound(%d) = %d, %v", name, i, got, ok)
+		}
+	}
+}
+
+// CheckOrderedMap 对空的有序映射 m 执行与 CheckOrderedSet 相同的流程，
+// 每次插入写入新值（键已存在时覆盖），并在 Find、LowerBound 与 ForEach 中检查值是否为最后写入的值
+func CheckOrderedMap(t *testing.T, name string, m gostl.OrderedMap[int, int]) {
+	t.Helper()
+	CheckOrderedSet(t, name, &mapAsSet{t: t, name: name, m: m, values: map[int]int{}})
+}
+
+// mapAsSet 将有序映射适配为有序集合，并记录每个键最后写入的值
+type mapAsSet struct {
+	t      *testing.T
+	name   string
+	m      gostl.OrderedMap[int, int]
+	values map[int]int
+	seq    int
+}
+
+func (s *mapAsSet) Len() int {
+	return s.m.Len()
+}
+
+func (s *mapAsSet) Clear() {
+	s.m.Clear()
+	clear(s.values)
+}
+
+func (s *mapAsSet) TryInsert(key int) bool {
+	oldLen := s.m.Len()
+	s.seq++
+	s.m.Insert(key, s.seq)
+	s.values[key] = s.seq
+	return s.m.Len() > oldLen
+}
+
+func (s *mapAsSet) Remove(key int) bool {
+	delete(s.values, key)
+	return s.m.Remove(key)
+}
+
+func (s *mapAsSet) Exist(key int) bool {
+	value := s.m.Find(key)
+	s.checkValue("Find", key, value)
+	return value != nil
+}
+
+func (s *mapAsSet) LowerBound(key int) (int, bool) {
+	k, value := s.m.LowerBound(key)
+	s.checkValue("LowerBound", k, value)
+	return k, value != nil
+}
+
+func (s *mapAsSet) ForEach(f func(key int)) {
+	s.m.ForEach(func(key int, value *int) {
+		s.checkValue("ForEach", key, value)
+		f(key)
+	})
+}
+
+func (s *mapAsSet) checkValue(method string, key int, value *int) {
+	s.t.Helper()
+	if want, ok := s.values[key]; value != nil && (!ok || *value != want) {
+		s.t.Fatalf("%s: %s: value of %d = %d, want %d", s.name, method, key, *value, want)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 const skipListMaxLevel = 40
 
+var _ gostl.OrderedMap[int, int] = (*SkipList[int, int])(nil)
+
 type SkipList[K any, V any] struct {
 	level      int                // 当前层级
 	length     int                // 跳表中拥有的元素总数
@@ -121,6 +123,46 @@ func (l *SkipList[K, V]) Remove(key K) bool {
 	return true
 }
 
+// LowerBound 返回第一个不小于 key 的键及其值的引用，如果不存在，值的引用为 nil
+func (l *SkipList[K, V]) LowerBound(key K) (K, *V) {
+	return l.nodeEntry(l.impl.lowerBound(key))
+}
+
+// UpperBound 返回第一个大于 key 的键及其值的引用，如果不存在，值的引用为 nil
+func (l *SkipList[K, V]) UpperBound(key K) (K, *V) {
+	return l.nodeEntry(l.impl.upperBound(key))
+}
+
+// KeyIterator 返回一个按升序依次产出跳表中所有键的迭代器，ok 为 false 表示遍历结束，
+// 可作为 heap.MergeK 的数据源，迭代期间不应修改跳表
+func (l *SkipList[K, V]) KeyIterator() func() (key K, ok bool) {
+	return l.keyIterator(l.head.next[0])
+}
+
+// KeyIteratorFrom 与 KeyIterator 相同，但从第一个不小于 key 的键开始
+func (l *SkipList[K, V]) KeyIteratorFrom(key K) func() (key K, ok bool) {
+	return l.keyIterator(l.impl.lowerBound(key))
+}
+
+func (l *SkipList[K, V]) keyIterator(node *skipListNode[K, V]) func() (K, bool) {
+	return func() (key K, ok bool) {
+		if node == nil {
+			return key, false
+		}
+		key = node.key
+		node = node.next[0]
+		return key, true
+	}
+}
+
+func (l *SkipList[K, V]) nodeEntry(node *skipListNode[K, V]) (K, *V) {
+	if node == nil {
+		var zero K
+		return zero, nil
+	}
+	return node.key, &node.value
+}
+
 // ForEach 遍历跳表，并为每个元素执行 f 函数
 func (l *SkipList[K, V]) ForEach(f func(key K, value *V)) {
 	for e := l.head.next[0]; e != nil; e = e.next[0] {
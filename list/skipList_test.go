@@ -0,0 +1,58 @@
+package list
+
+import (
+	"gostl/internal/containertest"
+	"sort"
+	"testing"
+)
+
+func Test_SkipListOrderedMap(t *testing.T) {
+	containertest.CheckOrderedMap(t, "SkipList", NewSkipList[int, int]())
+	containertest.CheckOrderedMap(t, "SkipListFunc", NewSkipListFunc[int, int](func(a, b int) int { return a - b }))
+}
+
+func Test_SkipListBounds(t *testing.T) {
+	for _, l := range []*SkipList[int, int]{
+		NewSkipList[int, int](),
+		NewSkipListFunc[int, int](func(a, b int) int { return a - b }),
+	} {
+		if _, v := l.LowerBound(0); v != nil {
+			t.Fatal("LowerBound() on an empty skip list")
+		}
+		if _, ok := l.KeyIterator()(); ok {
+			t.Fatal("KeyIterator() on an empty skip list")
+		}
+		keys := []int{}
+		for i := 0; i < 100; i++ {
+			l.Insert(i*3, i)
+			keys = append(keys, i*3)
+		}
+		for i := -1; i <= 300; i++ {
+			j := sort.SearchInts(keys, i+1) // 第一个大于 i 的键
+			key, value := l.UpperBound(i)
+			if (value != nil) != (j < len(keys)) || value != nil && (key != keys[j] || *value != keys[j]/3) {
+				t.Fatalf("UpperBound(%d) = %d, %v", i, key, value)
+			}
+
+			// KeyIteratorFrom 从第一个不小于 i 的键开始
+			next := l.KeyIteratorFrom(i)
+			for j := sort.SearchInts(keys, i); j < len(keys); j++ {
+				if key, ok := next(); !ok || key != keys[j] {
+					t.Fatalf("KeyIteratorFrom(%d) = %d, %v, want %d", i, key, ok, keys[j])
+				}
+			}
+			if _, ok := next(); ok {
+				t.Fatalf("KeyIteratorFrom(%d) did not stop", i)
+			}
+		}
+		next := l.KeyIterator()
+		for _, want := range keys {
+			if key, ok := next(); !ok || key != want {
+				t.Fatalf("KeyIterator() = %d, %v, want %d", key, ok, want)
+			}
+		}
+		if _, ok := next(); ok {
+			t.Fatal("KeyIterator() did not stop")
+		}
+	}
+}
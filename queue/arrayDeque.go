@@ -0,0 +1,220 @@
+package queue
+
+import "gostl"
+
+// ArrayDeque 双端队列，基于环形缓冲区的实现，两端插入删除的均摊时间复杂度为 O(1)，支持 O(1) 随机访问，
+// 方法名与 Deque 一致，可直接替换
+type ArrayDeque[T any] struct {
+	buf  []T
+	head int // 队首元素在 buf 中的下标
+	size int
+}
+
+// NewArrayDeque 构造一个空的双端队列
+func NewArrayDeque[T any]() ArrayDeque[T] {
+	return ArrayDeque[T]{}
+}
+
+// NewArrayDequeWithCapacity 构造一个有初始容量的双端队列
+func NewArrayDequeWithCapacity[T any](capacity int) ArrayDeque[T] {
+	return ArrayDeque[T]{buf: make([]T, capacity)}
+}
+
+// NewArrayDequeInitializerList 构造一个双端队列并用 values 按顺序初始化
+func NewArrayDequeInitializerList[T any](values ...T) ArrayDeque[T] {
+	buf := make([]T, len(values))
+	copy(buf, values)
+	return ArrayDeque[T]{buf: buf, size: len(values)}
+}
+
+// Len 获取双端队列长度
+func (q ArrayDeque[T]) Len() int {
+	return q.size
+}
+
+// Cap 获取双端队列的容量
+func (q ArrayDeque[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Empty 判断双端队列是否为空
+func (q ArrayDeque[T]) Empty() bool {
+	return q.size == 0
+}
+
+// Clear 清空双端队列，保留容量
+func (q *ArrayDeque[T]) Clear() {
+	q.forEachSegment(func(segment []T) bool {
+		gostl.FillZero(segment)
+		return true
+	})
+	q.head = 0
+	q.size = 0
+}
+
+// Front 获取双端队列队首元素，若双端队列为空则 panic
+func (q ArrayDeque[T]) Front() T {
+	if q.size == 0 {
+		panic("ArrayDeque.Front: empty deque")
+	}
+	return q.buf[q.head]
+}
+
+// Back 获取双端队列队尾元素，若双端队列为空则 panic
+func (q ArrayDeque[T]) Back() T {
+	if q.size == 0 {
+		panic("ArrayDeque.Back: empty deque")
+	}
+	return q.buf[q.index(q.size-1)]
+}
+
+// At 返回下标 idx（从队首开始计数）对应的元素，若越界则 panic
+func (q ArrayDeque[T]) At(idx int) T {
+	q.checkIndex(idx)
+	return q.buf[q.index(idx)]
+}
+
+// Set 设置下标 idx（从队首开始计数）对应的元素，若越界则 panic
+func (q *ArrayDeque[T]) Set(idx int, value T) {
+	q.checkIndex(idx)
+	q.buf[q.index(idx)] = value
+}
+
+// PushFront 在双端队列队首插入元素
+func (q *ArrayDeque[T]) PushFront(value T) {
+	q.grow()
+	q.head--
+	if q.head < 0 {
+		q.head += len(q.buf)
+	}
+	q.buf[q.head] = value
+	q.size++
+}
+
+// PushBack 在双端队列队尾插入元素
+func (q *ArrayDeque[T]) PushBack(value T) {
+	q.grow()
+	q.buf[q.index(q.size)] = value
+	q.size++
+}
+
+// PopFront 删除并返回双端队列队首元素，若双端队列为空则 panic
+func (q *ArrayDeque[T]) PopFront() T {
+	if q.size == 0 {
+		panic("ArrayDeque.PopFront: empty deque")
+	}
+	var zero T
+	value := q.buf[q.head]
+	q.buf[q.head] = zero
+	q.head = q.index(1)
+	q.size--
+	return value
+}
+
+// PopBack 删除并返回双端队列队尾元素，若双端队列为空则 panic
+func (q *ArrayDeque[T]) PopBack() T {
+	if q.size == 0 {
+		panic("ArrayDeque.PopBack: empty deque")
+	}
+	var zero T
+	i := q.index(q.size - 1)
+	value := q.buf[i]
+	q.buf[i] = zero
+	q.size--
+	return value
+}
+
+// Reserve 增加双端队列的容量至 capacity，如果目标容量小于当前容量，不做任何修改
+func (q *ArrayDeque[T]) Reserve(capacity int) {
+	if capacity > len(q.buf) {
+		q.resize(capacity)
+	}
+}
+
+// Shrink 将双端队列的容量调整至当前长度
+func (q *ArrayDeque[T]) Shrink() {
+	if q.size < len(q.buf) {
+		q.resize(q.size)
+	}
+}
+
+// Values 按从队首到队尾的顺序返回所有元素的拷贝
+func (q ArrayDeque[T]) Values() []T {
+	values := make([]T, 0, q.size)
+	q.forEachSegment(func(segment []T) bool {
+		values = append(values, segment...)
+		return true
+	})
+	return values
+}
+
+// ForEach 从队首到队尾遍历双端队列，并为每个元素执行 f 函数
+func (q *ArrayDeque[T]) ForEach(f func(value *T)) {
+	q.forEachSegment(func(segment []T) bool {
+		for i := range segment {
+			f(&segment[i])
+		}
+		return true
+	})
+}
+
+// ForEachIf 从队首到队尾遍历双端队列，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (q *ArrayDeque[T]) ForEachIf(f func(value *T) bool) {
+	q.forEachSegment(func(segment []T) bool {
+		for i := range segment {
+			if !f(&segment[i]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// index 将逻辑下标转换为 buf 中的下标
+func (q ArrayDeque[T]) index(idx int) int {
+	i := q.head + idx
+	if i >= len(q.buf) {
+		i -= len(q.buf)
+	}
+	return i
+}
+
+func (q ArrayDeque[T]) checkIndex(idx int) {
+	if idx < 0 || idx >= q.size {
+		panic("ArrayDeque: index out of range")
+	}
+}
+
+// forEachSegment 按顺序为存放元素的至多两段连续区间执行 f 函数，若 f 返回 false，直接返回
+func (q ArrayDeque[T]) forEachSegment(f func(segment []T) bool) {
+	if q.size == 0 {
+		return
+	}
+	end := q.head + q.size
+	if end <= len(q.buf) {
+		f(q.buf[q.head:end])
+		return
+	}
+	if f(q.buf[q.head:]) {
+		f(q.buf[:end-len(q.buf)])
+	}
+}
+
+// grow 在缓冲区已满时将容量翻倍
+func (q *ArrayDeque[T]) grow() {
+	if q.size == len(q.buf) {
+		q.resize(max(2*len(q.buf), 8))
+	}
+}
+
+// resize 将缓冲区重新分配为 capacity 大小，并将元素移动到缓冲区开头
+func (q *ArrayDeque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	n := 0
+	q.forEachSegment(func(segment []T) bool {
+		n += copy(buf[n:], segment)
+		return true
+	})
+	q.buf = buf
+	q.head = 0
+}
@@ -0,0 +1,129 @@
+package queue
+
+import (
+	"context"
+	"gostl"
+	"sync"
+)
+
+// BlockingPriorityQueue 并发安全的阻塞优先队列，Pop 在队列为空时阻塞，Push 在队列已满时阻塞，
+// 等待均可通过 context 取消
+type BlockingPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	pq       *PriorityQueue[T]
+	capacity int // 容量上限，0 表示无界
+	closed   bool
+	changed  notifier // 队列元素数量或关闭状态改变时广播
+}
+
+// NewBlockingPriorityQueue 构造一个可比较类型的阻塞优先队列，capacity 为容量上限，0 表示无界，小于 0 时 panic
+func NewBlockingPriorityQueue[T gostl.Ordered](capacity int, opts ...PriorityQueueOption) *BlockingPriorityQueue[T] {
+	return newBlockingPriorityQueue(NewPriorityQueue[T](opts...), capacity)
+}
+
+// NewBlockingPriorityQueueFunc 基于比较函数 less 构造一个阻塞优先队列，capacity 为容量上限，0 表示无界，小于 0 时 panic
+func NewBlockingPriorityQueueFunc[T any](less gostl.LessFunc[T], capacity int, opts ...PriorityQueueOption) *BlockingPriorityQueue[T] {
+	return newBlockingPriorityQueue(NewPriorityQueueFunc(less, opts...), capacity)
+}
+
+func newBlockingPriorityQueue[T any](pq *PriorityQueue[T], capacity int) *BlockingPriorityQueue[T] {
+	if capacity < 0 {
+		panic("queue.NewBlockingPriorityQueue: negative capacity")
+	}
+	return &BlockingPriorityQueue[T]{pq: pq, capacity: capacity}
+}
+
+// Len 获取队列中元素的数量
+func (q *BlockingPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Cap 获取队列的容量上限，0 表示无界
+func (q *BlockingPriorityQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// Push 插入元素，队列已满时阻塞直到有空位，
+// 若队列已关闭则返回 ErrQueueClosed，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingPriorityQueue[T]) Push(ctx context.Context, value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.await(ctx, func() bool { return !q.full() }); err != nil {
+		return err
+	}
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.push(value)
+	return nil
+}
+
+// TryPush 尝试插入元素，若队列已满或已关闭则直接返回 false
+func (q *BlockingPriorityQueue[T]) TryPush(value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.full() {
+		return false
+	}
+	q.push(value)
+	return true
+}
+
+// Pop 弹出优先级最高的元素，队列为空时阻塞直到有元素，
+// 若队列已关闭且为空则返回 ErrQueueClosed，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingPriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.await(ctx, func() bool { return !q.pq.Empty() }); err != nil {
+		return zero, err
+	}
+	if q.pq.Empty() {
+		return zero, ErrQueueClosed
+	}
+	return q.pop(), nil
+}
+
+// TryPop 尝试弹出优先级最高的元素，若队列为空则直接返回 false
+func (q *BlockingPriorityQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pq.Empty() {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// Close 关闭队列并唤醒所有等待者，之后的 Push 均返回 ErrQueueClosed，
+// 队列中剩余的元素仍可被 Pop，弹空后 Pop 返回 ErrQueueClosed，重复关闭无副作用
+func (q *BlockingPriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.changed.broadcast()
+}
+
+// await 在持有锁时调用，等待直到 ready 返回 true 或队列关闭，返回时仍持有锁，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingPriorityQueue[T]) await(ctx context.Context, ready func() bool) error {
+	return q.changed.await(ctx, &q.mu, func() bool {
+		return q.closed || ready()
+	})
+}
+
+func (q *BlockingPriorityQueue[T]) full() bool {
+	return q.capacity > 0 && q.pq.Len() >= q.capacity
+}
+
+func (q *BlockingPriorityQueue[T]) push(value T) {
+	q.pq.Push(value)
+	q.changed.broadcast()
+}
+
+func (q *BlockingPriorityQueue[T]) pop() T {
+	value := q.pq.Pop()
+	q.changed.broadcast()
+	return value
+}
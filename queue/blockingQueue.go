@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BlockingQueue 并发安全的有界阻塞先进先出队列，Take 在队列为空时阻塞，Put 在队列已满时阻塞，
+// 等待均可通过 context 取消
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	deque    ArrayDeque[T]
+	capacity int
+	closed   bool
+	changed  notifier // 队列元素数量、容量或关闭状态改变时广播
+}
+
+// NewBlockingQueue 构造一个容量为 capacity 的阻塞队列，capacity 小于 1 时 panic
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity < 1 {
+		panic("queue.NewBlockingQueue: capacity must be positive")
+	}
+	return &BlockingQueue[T]{
+		deque:    NewArrayDeque[T](),
+		capacity: capacity,
+	}
+}
+
+// Len 获取队列中元素的数量
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.deque.Len()
+}
+
+// Cap 获取队列的容量
+func (q *BlockingQueue[T]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.capacity
+}
+
+// Resize 将队列的容量调整为 capacity，若 capacity 小于当前元素数量，已有元素保留，
+// 直到元素数量降到 capacity 以下前 Put 均阻塞，capacity 小于 1 时 panic
+func (q *BlockingQueue[T]) Resize(capacity int) {
+	if capacity < 1 {
+		panic("BlockingQueue.Resize: capacity must be positive")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.capacity = capacity
+	q.changed.broadcast()
+}
+
+// Put 在队尾插入元素，队列已满时阻塞直到有空位，
+// 若队列已关闭则返回 ErrQueueClosed，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.await(ctx, q.notFull); err != nil {
+		return err
+	}
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.deque.PushBack(value)
+	q.changed.broadcast()
+	return nil
+}
+
+// Offer 在队尾插入元素，队列已满时最多等待 timeout，返回是否插入成功，timeout 不为正时不等待
+func (q *BlockingQueue[T]) Offer(value T, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return q.Put(ctx, value) == nil
+}
+
+// Take 删除并返回队首元素，队列为空时阻塞直到有元素，
+// 若队列已关闭且为空则返回 ErrQueueClosed，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.await(ctx, q.notEmpty); err != nil {
+		return zero, err
+	}
+	if q.deque.Empty() {
+		return zero, ErrQueueClosed
+	}
+	value := q.deque.PopFront()
+	q.changed.broadcast()
+	return value, nil
+}
+
+// Poll 删除并返回队首元素，队列为空时最多等待 timeout，返回是否成功，timeout 不为正时不等待
+func (q *BlockingQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	value, err := q.Take(ctx)
+	return value, err == nil
+}
+
+// Peek 返回队首元素但不删除，若队列为空则返回 false
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.deque.Empty() {
+		var zero T
+		return zero, false
+	}
+	return q.deque.Front(), true
+}
+
+// DrainTo 不阻塞地删除至多 limit 个队首元素并按顺序追加到 dst，limit 不为正时删除所有元素，返回删除的元素数量
+func (q *BlockingQueue[T]) DrainTo(dst *[]T, limit int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := q.deque.Len()
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	for i := 0; i < n; i++ {
+		*dst = append(*dst, q.deque.PopFront())
+	}
+	if n > 0 {
+		q.changed.broadcast()
+	}
+	return n
+}
+
+// Close 关闭队列并唤醒所有等待者，之后的 Put 均返回 ErrQueueClosed，
+// 队列中剩余的元素仍可被取出，取空后 Take 返回 ErrQueueClosed，重复关闭无副作用
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.changed.broadcast()
+}
+
+// await 在持有锁时调用，等待直到 ready 返回 true 或队列关闭，返回时仍持有锁，若 ctx 被取消则返回 ctx.Err()
+func (q *BlockingQueue[T]) await(ctx context.Context, ready func() bool) error {
+	return q.changed.await(ctx, &q.mu, func() bool {
+		return q.closed || ready()
+	})
+}
+
+func (q *BlockingQueue[T]) notFull() bool {
+	return q.deque.Len() < q.capacity
+}
+
+func (q *BlockingQueue[T]) notEmpty() bool {
+	return !q.deque.Empty()
+}
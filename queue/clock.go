@@ -0,0 +1,158 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock 时间源，DelayQueue 与 TimingWheel 通过它获取当前时间并等待，便于在测试中注入
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+	// NewTimer 返回一个在 d 时间后向 C() 发送当前时间的定时器，不再需要时应调用 Stop 释放
+	NewTimer(d time.Duration) ClockTimer
+}
+
+// ClockTimer Clock 创建的一次性定时器
+type ClockTimer interface {
+	// C 返回定时器到期时收到当前时间的 channel
+	C() <-chan time.Time
+	// Stop 停止定时器，若定时器已到期或已被停止则返回 false
+	Stop() bool
+}
+
+// SystemClock 基于系统时间的 Clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) ClockTimer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// ManualClock 手动推进的 Clock，时间只在调用 Advance 或 Set 时改变，用于测试
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*manualTimer
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewManualClock 构造一个当前时间为 now 的 ManualClock
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now 返回当前时间
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer 返回一个在时间被推进 d 后到期的定时器
+func (c *ManualClock) NewTimer(d time.Duration) ClockTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.waiters = append(c.waiters, t)
+	return t
+}
+
+// Advance 将时间推进 d，并唤醒所有到期的等待者
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set 将时间设置为 now，并唤醒所有到期的等待者，不能将时间回拨
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(now)
+}
+
+// Waiters 返回尚未到期且未被停止的定时器数量，测试中可用它确认被测对象已经开始等待
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func (c *ManualClock) set(now time.Time) {
+	if now.Before(c.now) {
+		panic("ManualClock: time cannot go backwards")
+	}
+	c.now = now
+	waiters := c.waiters[:0]
+	for _, t := range c.waiters {
+		if t.deadline.After(now) {
+			waiters = append(waiters, t)
+		} else {
+			t.ch <- now
+		}
+	}
+	clear(c.waiters[len(waiters):])
+	c.waiters = waiters
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w == t {
+			last := len(c.waiters) - 1
+			copy(c.waiters[i:], c.waiters[i+1:])
+			c.waiters[last] = nil
+			c.waiters = c.waiters[:last]
+			return true
+		}
+	}
+	return false
+}
+
+// waitTimer 阻塞直到 wait 被关闭、timer 到期或 ctx 被取消，timer 为 nil 时只等待前两者，
+// 返回前停止 timer，ctx 被取消时返回 ctx.Err()
+func waitTimer(ctx context.Context, wait <-chan struct{}, timer ClockTimer) error {
+	var expired <-chan time.Time
+	if timer != nil {
+		defer timer.Stop()
+		expired = timer.C()
+	}
+	select {
+	case <-wait:
+		return nil
+	case <-expired:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
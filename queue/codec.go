@@ -0,0 +1,22 @@
+package queue
+
+import "encoding/json"
+
+// Codec 元素的编解码器，DiskQueue 通过它将元素序列化到磁盘
+type Codec[T any] interface {
+	Encode(value T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec 基于 encoding/json 的编解码器
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
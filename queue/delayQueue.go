@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue 并发安全的延迟队列，元素在到达其截止时间后才能被取出，
+// 截止时间相同的元素按插入顺序取出
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	pq      *PriorityQueue[delayItem[T]]
+	clock   Clock
+	closed  bool
+	changed notifier // 队首元素或关闭状态改变时广播
+}
+
+type delayItem[T any] struct {
+	value    T
+	deadline time.Time
+}
+
+// NewDelayQueue 构造一个空的延迟队列，clock 为 nil 时使用 SystemClock
+func NewDelayQueue[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &DelayQueue[T]{
+		pq: NewPriorityQueueFunc(func(a, b delayItem[T]) bool {
+			return a.deadline.Before(b.deadline)
+		}, WithStable()),
+		clock: clock,
+	}
+}
+
+// Len 获取队列中元素的数量，包括尚未到期的元素
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Push 插入一个在 deadline 到期的元素，若队列已关闭则返回 ErrQueueClosed
+func (q *DelayQueue[T]) Push(value T, deadline time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.pq.Push(delayItem[T]{value, deadline})
+	if q.pq.Top().deadline.Equal(deadline) {
+		q.changed.broadcast()
+	}
+	return nil
+}
+
+// PushDelay 插入一个在 delay 时间后到期的元素，若队列已关闭则返回 ErrQueueClosed
+func (q *DelayQueue[T]) PushDelay(value T, delay time.Duration) error {
+	return q.Push(value, q.clock.Now().Add(delay))
+}
+
+// Peek 返回最早到期的元素及其截止时间，不论是否已经到期，若队列为空则返回 false
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pq.Empty() {
+		var zero T
+		return zero, time.Time{}, false
+	}
+	item := q.pq.Top()
+	return item.value, item.deadline, true
+}
+
+// Poll 取出一个已到期的元素，若没有已到期的元素则直接返回 false
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pq.Empty() || q.pq.Top().deadline.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+	return q.pq.Pop().value, true
+}
+
+// Take 取出一个已到期的元素，若没有则阻塞直到队首元素到期，若 ctx 被取消则返回 ctx.Err()
+//
+//	队列关闭后 Take 不再等待：仍会取出已到期的元素，否则返回 ErrQueueClosed
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	var zero T
+	q.mu.Lock()
+	for {
+		var timer ClockTimer
+		if !q.pq.Empty() {
+			d := q.pq.Top().deadline.Sub(q.clock.Now())
+			if d <= 0 {
+				value := q.pq.Pop().value
+				q.mu.Unlock()
+				return value, nil
+			}
+			if !q.closed {
+				timer = q.clock.NewTimer(d)
+			}
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return zero, ErrQueueClosed
+		}
+		wait := q.changed.wait()
+		q.mu.Unlock()
+		if err := waitTimer(ctx, wait, timer); err != nil {
+			return zero, err
+		}
+		q.mu.Lock()
+	}
+}
+
+// Close 关闭队列并唤醒所有等待者，之后的 Push 均返回 ErrQueueClosed，重复关闭无副作用
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.changed.broadcast()
+}
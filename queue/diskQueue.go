@@ -0,0 +1,562 @@
+package queue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DiskQueue 基于本地目录的持久化先进先出队列，并发安全
+//
+//	元素以追加写的方式写入分段文件，每条记录为 [4 字节长度][4 字节 CRC32][数据]；
+//	Pop 只移动内存中的读游标，Commit 才将读游标持久化并删除已消费完的分段，
+//	重新打开时从已提交的游标继续读取（至少一次语义），并截断崩溃时写了一半的记录；
+//	同一目录同时只能被一个 DiskQueue 打开，unix 平台上通过目录中的锁文件保证
+type DiskQueue[T any] struct {
+	mu       sync.Mutex
+	dir      string
+	codec    Codec[T]
+	opts     diskQueueOptions
+	lockFile *os.File
+
+	writeFile *os.File
+	writeSeg  uint64 // 当前写入的分段编号
+	writeOff  int64  // 当前写入分段的大小
+
+	readFile *os.File
+	readSeg  uint64 // 当前读取的分段编号
+	readOff  int64  // 下一条记录在读取分段中的偏移
+
+	commitSeg uint64 // 已提交的读游标
+	commitOff int64
+
+	count       int // 未读取的记录数量
+	uncommitted int // 已读取但未提交的记录数量
+	closed      bool
+}
+
+// DiskQueueOption DiskQueue 的构造选项
+type DiskQueueOption func(opts *diskQueueOptions)
+
+type diskQueueOptions struct {
+	segmentSize int64
+	syncOnPush  bool
+}
+
+// WithSegmentSize 指定分段文件的大小上限（字节），写满后滚动到新分段，默认为 64 MiB，size 不为正时 panic
+func WithSegmentSize(size int64) DiskQueueOption {
+	if size <= 0 {
+		panic("queue.WithSegmentSize: size must be positive")
+	}
+	return func(opts *diskQueueOptions) {
+		opts.segmentSize = size
+	}
+}
+
+// WithSyncOnPush 使每次 Push 后都调用 fsync，牺牲吞吐量换取掉电时不丢失已 Push 的元素
+func WithSyncOnPush() DiskQueueOption {
+	return func(opts *diskQueueOptions) {
+		opts.syncOnPush = true
+	}
+}
+
+const (
+	diskQueueRecordHeaderSize = 8
+	diskQueueCursorFile       = "cursor"
+	diskQueueLockFile         = "lock"
+	diskQueueSegmentSuffix    = ".seg"
+)
+
+// OpenDiskQueue 打开（或创建）目录 dir 下的持久化队列，codec 用于元素的编解码，
+// 若目录已被其他 DiskQueue 打开则返回 ErrQueueLocked
+func OpenDiskQueue[T any](dir string, codec Codec[T], opts ...DiskQueueOption) (*DiskQueue[T], error) {
+	o := diskQueueOptions{segmentSize: 64 << 20}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	lockFile, err := lockDiskQueueDir(filepath.Join(dir, diskQueueLockFile))
+	if err != nil {
+		return nil, err
+	}
+	q := &DiskQueue[T]{dir: dir, codec: codec, opts: o, lockFile: lockFile}
+	if err := q.recover(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+	return q, nil
+}
+
+// Len 获取未读取的元素数量
+func (q *DiskQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// Empty 判断是否没有未读取的元素
+func (q *DiskQueue[T]) Empty() bool {
+	return q.Len() == 0
+}
+
+// Push 在队尾追加元素
+func (q *DiskQueue[T]) Push(value T) error {
+	data, err := q.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if q.writeOff > 0 && q.writeOff+diskQueueRecordHeaderSize+int64(len(data)) > q.opts.segmentSize {
+		if err := q.roll(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]byte, diskQueueRecordHeaderSize+len(data))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(data))
+	copy(record[diskQueueRecordHeaderSize:], data)
+	if _, err := q.writeFile.Write(record); err != nil {
+		// 丢弃只写了一部分的记录，使文件与 writeOff 保持一致
+		if truncateErr := q.writeFile.Truncate(q.writeOff); truncateErr != nil {
+			return errors.Join(err, truncateErr)
+		}
+		return err
+	}
+	q.writeOff += int64(len(record))
+	q.count++
+	if q.opts.syncOnPush {
+		return q.writeFile.Sync()
+	}
+	return nil
+}
+
+// Pop 读取并返回队首元素，若没有未读取的元素则返回 ErrQueueEmpty，
+// 若记录无法解码则跳过该记录并返回 *DecodeError，若记录损坏或分段丢失则跳过损坏的部分并返回 *CorruptionError，
+// 读取的元素在 Commit 之前不会从磁盘删除，重新打开或 Rollback 后会再次被读取
+func (q *DiskQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	value, n, err := q.peek()
+	if n == 0 {
+		var corruptionErr *CorruptionError
+		if errors.As(err, &corruptionErr) { // 记录的边界不可信，放弃所在分段的剩余部分
+			if skipErr := q.skipSegment(); skipErr != nil {
+				return value, errors.Join(err, skipErr)
+			}
+		}
+		return value, err
+	}
+	// 读取成功，或记录完整但无法解码、校验失败，均跳过这条记录
+	q.readOff += n
+	q.count--
+	q.uncommitted++
+	return value, err
+}
+
+// Peek 返回队首元素但不读取它，若没有未读取的元素则返回 ErrQueueEmpty，
+// 若记录无法解码则返回 *DecodeError，若记录损坏或分段丢失则返回 *CorruptionError
+func (q *DiskQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	value, _, err := q.peek()
+	return value, err
+}
+
+// Commit 持久化读游标，已读取的元素不会再被读取，并删除已消费完的分段文件
+func (q *DiskQueue[T]) Commit() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if err := q.writeCursor(q.readSeg, q.readOff); err != nil {
+		return err
+	}
+	for seg := q.commitSeg; seg < q.readSeg; seg++ {
+		if err := os.Remove(q.segmentPath(seg)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	q.commitSeg, q.commitOff = q.readSeg, q.readOff
+	q.uncommitted = 0
+	return nil
+}
+
+// Rollback 将读游标回退到上一次 Commit 的位置，之后读取的元素会被再次读取
+func (q *DiskQueue[T]) Rollback() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if err := q.openReadSegment(q.commitSeg); err != nil {
+		return err
+	}
+	q.readOff = q.commitOff
+	q.count += q.uncommitted
+	q.uncommitted = 0
+	return nil
+}
+
+// Close 将已写入的数据刷到磁盘并关闭队列，不会提交读游标，重复关闭无副作用
+func (q *DiskQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	var err error
+	if q.writeFile != nil {
+		err = q.writeFile.Sync()
+	}
+	if closeErr := q.closeFiles(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// peek 读取读游标处的记录，返回元素及记录占用的字节数，必要时切换到下一个分段；
+// 解码失败或校验失败时也返回记录占用的字节数，记录的边界不可信或分段丢失时返回 0
+func (q *DiskQueue[T]) peek() (value T, n int64, err error) {
+	if q.closed {
+		return value, 0, ErrQueueClosed
+	}
+	if q.count == 0 {
+		return value, 0, ErrQueueEmpty
+	}
+	for {
+		data, n, err := readDiskQueueRecord(q.readFile, q.readOff)
+		if err == io.EOF && q.readSeg < q.writeSeg {
+			if err := q.openReadSegment(q.readSeg + 1); err != nil {
+				return value, 0, &CorruptionError{q.readSeg + 1, 0, err}
+			}
+			q.readOff = 0
+			continue
+		}
+		if err == errDiskQueueChecksum {
+			return value, n, &CorruptionError{q.readSeg, q.readOff, err}
+		}
+		if err != nil { // 未读取的记录均已完整写入，读到文件末尾或不完整的记录说明文件被破坏
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return value, 0, &CorruptionError{q.readSeg, q.readOff, err}
+		}
+		if value, err = q.codec.Decode(data); err != nil {
+			return value, n, &DecodeError{err}
+		}
+		return value, n, nil
+	}
+}
+
+// recover 读取游标并校验游标之后的所有分段，截断损坏的记录，统计未读取的记录数量
+func (q *DiskQueue[T]) recover() error {
+	segments, err := q.listSegments()
+	if err != nil {
+		return err
+	}
+	if err := q.readCursor(); err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		segments = []uint64{q.commitSeg}
+	}
+	if q.commitSeg < segments[0] { // 游标指向的分段已被删除，说明其中的记录均已消费
+		q.commitSeg, q.commitOff = segments[0], 0
+	}
+
+	for _, seg := range segments {
+		if seg < q.commitSeg {
+			if err := os.Remove(q.segmentPath(seg)); err != nil {
+				return err
+			}
+			continue
+		}
+		off := int64(0)
+		if seg == q.commitSeg {
+			off = q.commitOff
+		}
+		count, size, err := q.scanSegment(seg, off)
+		if err != nil {
+			return err
+		}
+		q.count += count
+		q.writeSeg, q.writeOff = seg, size
+		if seg == q.commitSeg {
+			q.commitOff = min(q.commitOff, size)
+		}
+	}
+	if q.writeSeg < q.commitSeg {
+		q.writeSeg, q.writeOff = q.commitSeg, 0
+	}
+
+	q.writeFile, err = os.OpenFile(q.segmentPath(q.writeSeg), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := q.openReadSegment(q.commitSeg); err != nil {
+		return err
+	}
+	q.readOff = q.commitOff
+	return nil
+}
+
+// scanSegment 从 off 开始校验分段中的记录，将分段截断到最后一条完整的记录，返回记录数量与截断后的大小
+func (q *DiskQueue[T]) scanSegment(seg uint64, off int64) (int, int64, error) {
+	file, err := os.OpenFile(q.segmentPath(seg), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	if off > info.Size() { // 未 fsync 的尾部在掉电后丢失，而其中的记录已被提交消费
+		return 0, info.Size(), nil
+	}
+
+	count := 0
+	for {
+		_, n, err := readDiskQueueRecord(file, off)
+		if err != nil {
+			break // 到达文件末尾或遇到损坏的记录
+		}
+		off += n
+		count++
+	}
+	if off < info.Size() {
+		if err := file.Truncate(off); err != nil {
+			return 0, 0, err
+		}
+	}
+	return count, off, nil
+}
+
+// errDiskQueueChecksum 记录完整但校验失败
+var errDiskQueueChecksum = errors.New("queue: record checksum mismatch")
+
+// readDiskQueueRecord 读取 off 处的记录，返回数据与记录占用的字节数，
+// 若 off 恰为文件末尾则返回 io.EOF，若记录不完整则返回 io.ErrUnexpectedEOF，
+// 若记录完整但校验失败则返回 errDiskQueueChecksum 及记录占用的字节数
+func readDiskQueueRecord(file *os.File, off int64) ([]byte, int64, error) {
+	var header [diskQueueRecordHeaderSize]byte
+	if n, err := file.ReadAt(header[:], off); n == 0 && err == io.EOF {
+		return nil, 0, io.EOF
+	} else if n < len(header) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	length := binary.LittleEndian.Uint32(header[0:4])
+	if length > 1<<20 { // 损坏的长度字段可能导致超大的内存分配，先与文件大小比较
+		if info, err := file.Stat(); err != nil || off+diskQueueRecordHeaderSize+int64(length) > info.Size() {
+			return nil, 0, io.ErrUnexpectedEOF
+		}
+	}
+	data := make([]byte, length)
+	if n, _ := file.ReadAt(data, off+diskQueueRecordHeaderSize); n < len(data) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[4:8]) {
+		return nil, diskQueueRecordHeaderSize + int64(length), errDiskQueueChecksum
+	}
+	return data, diskQueueRecordHeaderSize + int64(length), nil
+}
+
+// roll 关闭当前写入分段并创建下一个分段
+func (q *DiskQueue[T]) roll() error {
+	if err := q.writeFile.Sync(); err != nil {
+		return err
+	}
+	if err := q.writeFile.Close(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(q.segmentPath(q.writeSeg+1), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	q.writeFile = file
+	q.writeSeg++
+	q.writeOff = 0
+	return nil
+}
+
+// skipSegment 放弃当前读取分段中剩余的记录，将读游标移到下一个存在的分段的开头（当前分段为写入分段时移到末尾），
+// 由于无法得知被放弃的记录数量，从新的读游标开始重新统计未读取的记录，时间复杂度与剩余数据量成正比
+func (q *DiskQueue[T]) skipSegment() error {
+	if q.readSeg >= q.writeSeg {
+		q.readOff = q.writeOff
+	} else {
+		segments, err := q.listSegments()
+		if err != nil {
+			return err
+		}
+		next := q.writeSeg
+		for _, seg := range segments {
+			if seg > q.readSeg {
+				next = seg
+				break
+			}
+		}
+		if err := q.openReadSegment(next); err != nil {
+			return err
+		}
+		q.readOff = 0
+	}
+
+	q.count = 0
+	for seg, off := q.readSeg, q.readOff; seg <= q.writeSeg; seg, off = seg+1, 0 {
+		file, err := os.Open(q.segmentPath(seg))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for {
+			_, n, err := readDiskQueueRecord(file, off)
+			if err != nil {
+				break
+			}
+			off += n
+			q.count++
+		}
+		file.Close()
+	}
+	return nil
+}
+
+func (q *DiskQueue[T]) openReadSegment(seg uint64) error {
+	if q.readFile != nil && q.readSeg == seg {
+		return nil
+	}
+	file, err := os.Open(q.segmentPath(seg))
+	if err != nil {
+		return err
+	}
+	if q.readFile != nil {
+		q.readFile.Close()
+	}
+	q.readFile = file
+	q.readSeg = seg
+	return nil
+}
+
+func (q *DiskQueue[T]) closeFiles() error {
+	var err error
+	if q.writeFile != nil {
+		err = q.writeFile.Close()
+		q.writeFile = nil
+	}
+	if q.readFile != nil {
+		if closeErr := q.readFile.Close(); err == nil {
+			err = closeErr
+		}
+		q.readFile = nil
+	}
+	if q.lockFile != nil {
+		if closeErr := q.lockFile.Close(); err == nil {
+			err = closeErr
+		}
+		q.lockFile = nil
+	}
+	return err
+}
+
+func (q *DiskQueue[T]) segmentPath(seg uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", seg, diskQueueSegmentSuffix))
+}
+
+// listSegments 返回目录中所有分段的编号，按升序排列
+func (q *DiskQueue[T]) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), diskQueueSegmentSuffix)
+		if !ok {
+			continue
+		}
+		if seg, err := strconv.ParseUint(name, 10, 64); err == nil {
+			segments = append(segments, seg)
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// readCursor 读取已提交的游标，游标文件不存在时从头开始
+func (q *DiskQueue[T]) readCursor() error {
+	data, err := os.ReadFile(filepath.Join(q.dir, diskQueueCursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) != 20 || crc32.ChecksumIEEE(data[:16]) != binary.LittleEndian.Uint32(data[16:]) {
+		return errors.New("queue: corrupted cursor file")
+	}
+	q.commitSeg = binary.LittleEndian.Uint64(data[0:8])
+	q.commitOff = int64(binary.LittleEndian.Uint64(data[8:16]))
+	return nil
+}
+
+// writeCursor 通过写临时文件再重命名的方式原子地持久化游标，并同步目录使重命名在崩溃后仍然有效
+func (q *DiskQueue[T]) writeCursor(seg uint64, off int64) error {
+	var data [20]byte
+	binary.LittleEndian.PutUint64(data[0:8], seg)
+	binary.LittleEndian.PutUint64(data[8:16], uint64(off))
+	binary.LittleEndian.PutUint32(data[16:20], crc32.ChecksumIEEE(data[:16]))
+
+	path := filepath.Join(q.dir, diskQueueCursorFile)
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data[:]); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	return syncDir(q.dir)
+}
+
+// syncDir 将目录项的修改刷到磁盘
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
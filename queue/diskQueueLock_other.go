@@ -0,0 +1,10 @@
+//go:build !unix
+
+package queue
+
+import "os"
+
+// lockDiskQueueDir 在目录中创建锁文件，非 unix 平台上不加锁，调用方需保证同一目录只被一个 DiskQueue 打开
+func lockDiskQueueDir(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+}
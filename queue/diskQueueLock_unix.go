@@ -0,0 +1,25 @@
+//go:build unix
+
+package queue
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockDiskQueueDir 在目录中创建锁文件并加排他锁，锁在文件关闭或进程退出时释放
+func lockDiskQueueDir(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, ErrQueueLocked
+		}
+		return nil, err
+	}
+	return file, nil
+}
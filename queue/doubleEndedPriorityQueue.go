@@ -0,0 +1,147 @@
+package queue
+
+import (
+	"gostl"
+	"gostl/heap"
+)
+
+// DoubleEndedPriorityQueue 双端优先队列，基于最小最大堆实现，可同时获取与弹出最小元素和最大元素
+type DoubleEndedPriorityQueue[T any] struct {
+	heap []T
+	impl depqImpl[T]
+}
+
+// NewDoubleEndedPriorityQueue 构造一个可比较类型的双端优先队列
+func NewDoubleEndedPriorityQueue[T gostl.Ordered]() *DoubleEndedPriorityQueue[T] {
+	pq := depqOrdered[T]{}
+	pq.impl = (depqImpl[T])(&pq)
+	return &pq.DoubleEndedPriorityQueue
+}
+
+// NewDoubleEndedPriorityQueueInitializerList 构造一个可比较类型的双端优先队列，并用 initializerList 初始化
+func NewDoubleEndedPriorityQueueInitializerList[T gostl.Ordered](values ...T) *DoubleEndedPriorityQueue[T] {
+	heap.NewMinMaxHeap(&values)
+	pq := depqOrdered[T]{}
+	pq.heap = values
+	pq.impl = (depqImpl[T])(&pq)
+	return &pq.DoubleEndedPriorityQueue
+}
+
+// NewDoubleEndedPriorityQueueFunc 基于比较函数 less 构造一个双端优先队列
+func NewDoubleEndedPriorityQueueFunc[T any](less gostl.LessFunc[T]) *DoubleEndedPriorityQueue[T] {
+	pq := depqFunc[T]{}
+	pq.less = less
+	pq.impl = (depqImpl[T])(&pq)
+	return &pq.DoubleEndedPriorityQueue
+}
+
+// NewDoubleEndedPriorityQueueFuncInitializerList 基于比较函数 less 构造一个双端优先队列，并用 initializerList 初始化
+func NewDoubleEndedPriorityQueueFuncInitializerList[T any](less gostl.LessFunc[T], values ...T) *DoubleEndedPriorityQueue[T] {
+	heap.NewMinMaxHeapFunc(&values, less)
+	pq := depqFunc[T]{}
+	pq.less = less
+	pq.heap = values
+	pq.impl = (depqImpl[T])(&pq)
+	return &pq.DoubleEndedPriorityQueue
+}
+
+// Len 获取当前 double-ended priority queue 节点数
+func (pq *DoubleEndedPriorityQueue[T]) Len() int {
+	return len(pq.heap)
+}
+
+// Empty 获取 double-ended priority queue 是否为空
+func (pq *DoubleEndedPriorityQueue[T]) Empty() bool {
+	return len(pq.heap) == 0
+}
+
+// Clear 清空当前 double-ended priority queue
+func (pq *DoubleEndedPriorityQueue[T]) Clear() {
+	gostl.FillZero(pq.heap)
+	pq.heap = pq.heap[:0]
+}
+
+// PeekMin 获取 double-ended priority queue 的最小元素，若 double-ended priority queue 为空则 panic
+func (pq *DoubleEndedPriorityQueue[T]) PeekMin() T {
+	if len(pq.heap) == 0 {
+		panic("DoubleEndedPriorityQueue.PeekMin: empty queue")
+	}
+	return pq.heap[0]
+}
+
+// PeekMax 获取 double-ended priority queue 的最大元素，若 double-ended priority queue 为空则 panic
+func (pq *DoubleEndedPriorityQueue[T]) PeekMax() T {
+	if len(pq.heap) == 0 {
+		panic("DoubleEndedPriorityQueue.PeekMax: empty queue")
+	}
+	return pq.heap[pq.impl.MaxIndex()]
+}
+
+// Push 向 double-ended priority queue 插入元素，时间复杂度 O(log(pq.Len()))
+func (pq *DoubleEndedPriorityQueue[T]) Push(value T) {
+	pq.impl.Push(value)
+}
+
+// PopMin 从 double-ended priority queue 弹出最小元素，并返回，若 double-ended priority queue 为空则 panic，时间复杂度 O(log(pq.Len()))
+func (pq *DoubleEndedPriorityQueue[T]) PopMin() T {
+	if len(pq.heap) == 0 {
+		panic("DoubleEndedPriorityQueue.PopMin: empty queue")
+	}
+	return pq.impl.PopMin()
+}
+
+// PopMax 从 double-ended priority queue 弹出最大元素，并返回，若 double-ended priority queue 为空则 panic，时间复杂度 O(log(pq.Len()))
+func (pq *DoubleEndedPriorityQueue[T]) PopMax() T {
+	if len(pq.heap) == 0 {
+		panic("DoubleEndedPriorityQueue.PopMax: empty queue")
+	}
+	return pq.impl.PopMax()
+}
+
+type depqImpl[T any] interface {
+	Push(value T)
+	PopMin() T
+	PopMax() T
+	MaxIndex() int
+}
+
+type depqOrdered[T gostl.Ordered] struct {
+	DoubleEndedPriorityQueue[T]
+}
+
+func (q *depqOrdered[T]) Push(value T) {
+	heap.PushMinMaxHeap(&q.heap, value)
+}
+
+func (q *depqOrdered[T]) PopMin() T {
+	return heap.PopMinMaxHeapMin(&q.heap)
+}
+
+func (q *depqOrdered[T]) PopMax() T {
+	return heap.PopMinMaxHeapMax(&q.heap)
+}
+
+func (q *depqOrdered[T]) MaxIndex() int {
+	return heap.MinMaxHeapMaxIndex(q.heap)
+}
+
+type depqFunc[T any] struct {
+	DoubleEndedPriorityQueue[T]
+	less gostl.LessFunc[T]
+}
+
+func (q *depqFunc[T]) Push(value T) {
+	heap.PushMinMaxHeapFunc(&q.heap, value, q.less)
+}
+
+func (q *depqFunc[T]) PopMin() T {
+	return heap.PopMinMaxHeapMinFunc(&q.heap, q.less)
+}
+
+func (q *depqFunc[T]) PopMax() T {
+	return heap.PopMinMaxHeapMaxFunc(&q.heap, q.less)
+}
+
+func (q *depqFunc[T]) MaxIndex() int {
+	return heap.MinMaxHeapMaxIndexFunc(q.heap, q.less)
+}
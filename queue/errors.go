@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQueueClosed 队列已关闭
+var ErrQueueClosed = errors.New("queue: queue closed")
+
+// ErrQueueEmpty 队列为空
+var ErrQueueEmpty = errors.New("queue: queue empty")
+
+// ErrQueueLocked DiskQueue 的目录已被其他 DiskQueue 打开
+var ErrQueueLocked = errors.New("queue: queue directory locked")
+
+// DecodeError DiskQueue 中的记录校验通过但 Codec 无法解码，Pop 返回该错误时已跳过这条记录
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return "queue: decode record: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// CorruptionError DiskQueue 读取时发现损坏的记录或丢失的分段，Pop 返回该错误时已跳过损坏的部分：
+// 记录的长度可信时只跳过这条记录，否则跳过所在分段的剩余部分
+type CorruptionError struct {
+	Segment uint64 // 损坏位置所在的分段
+	Offset  int64  // 损坏位置在分段中的偏移
+	Err     error
+}
+
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("queue: corrupted segment %d at offset %d: %v", e.Segment, e.Offset, e.Err)
+}
+
+func (e *CorruptionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"gostl"
+	"gostl/heap"
+)
+
+// PQHandle 索引优先队列中元素的句柄，由 Push 返回，用于 Update、Remove 与 Contains
+type PQHandle[T any] struct {
+	value T
+	index int // 元素在堆中的下标，不在队列中时为 -1
+}
+
+// Value 返回句柄对应的元素
+func (h *PQHandle[T]) Value() T {
+	return h.value
+}
+
+// IndexedPriorityQueue 索引优先队列，在 PriorityQueue 的基础上支持修改与删除任意元素
+type IndexedPriorityQueue[T any] struct {
+	heap []*PQHandle[T]
+	impl ipqImpl[T]
+}
+
+// NewIndexedPriorityQueue 构造一个可比较类型的索引优先队列
+func NewIndexedPriorityQueue[T gostl.Ordered]() *IndexedPriorityQueue[T] {
+	pq := ipqOrdered[T]{}
+	pq.impl = (ipqImpl[T])(&pq)
+	return &pq.IndexedPriorityQueue
+}
+
+// NewIndexedPriorityQueueFunc 基于比较函数 less 构造一个索引优先队列
+func NewIndexedPriorityQueueFunc[T any](less gostl.LessFunc[T]) *IndexedPriorityQueue[T] {
+	pq := ipqFunc[T]{}
+	pq.lessFunc = less
+	pq.impl = (ipqImpl[T])(&pq)
+	return &pq.IndexedPriorityQueue
+}
+
+// Len 获取当前 indexed priority queue 节点数
+func (pq *IndexedPriorityQueue[T]) Len() int {
+	return len(pq.heap)
+}
+
+// Empty 获取 indexed priority queue 是否为空
+func (pq *IndexedPriorityQueue[T]) Empty() bool {
+	return len(pq.heap) == 0
+}
+
+// Clear 清空当前 indexed priority queue，所有句柄均失效
+func (pq *IndexedPriorityQueue[T]) Clear() {
+	for i, h := range pq.heap {
+		h.index = -1
+		pq.heap[i] = nil
+	}
+	pq.heap = pq.heap[:0]
+}
+
+// Top 获取 indexed priority queue 头部元素，若 indexed priority queue 为空则 panic
+func (pq *IndexedPriorityQueue[T]) Top() T {
+	return pq.heap[0].value
+}
+
+// TopHandle 获取 indexed priority queue 头部元素的句柄，若 indexed priority queue 为空则 panic
+func (pq *IndexedPriorityQueue[T]) TopHandle() *PQHandle[T] {
+	return pq.heap[0]
+}
+
+// Push 向 indexed priority queue 插入元素，并返回该元素的句柄，时间复杂度 O(log(pq.Len()))
+func (pq *IndexedPriorityQueue[T]) Push(value T) *PQHandle[T] {
+	h := &PQHandle[T]{value: value, index: len(pq.heap)}
+	pq.heap = append(pq.heap, h)
+	pq.up(h.index)
+	return h
+}
+
+// Pop 从 indexed priority queue 弹出头部元素，并返回，时间复杂度 O(log(pq.Len()))
+func (pq *IndexedPriorityQueue[T]) Pop() T {
+	return pq.Remove(pq.heap[0])
+}
+
+// Contains 判断句柄对应的元素是否仍在 indexed priority queue 中
+func (pq *IndexedPriorityQueue[T]) Contains(h *PQHandle[T]) bool {
+	return h.index >= 0 && h.index < len(pq.heap) && pq.heap[h.index] == h
+}
+
+// Update 修改句柄对应的元素并调整其位置，句柄不在队列中时 panic，时间复杂度 O(log(pq.Len()))
+//
+//	既可以提高优先级（DecreaseKey），也可以降低优先级
+func (pq *IndexedPriorityQueue[T]) Update(h *PQHandle[T], value T) {
+	if !pq.Contains(h) {
+		panic("IndexedPriorityQueue.Update: handle is not in the queue")
+	}
+	h.value = value
+	if !pq.down(h.index, len(pq.heap)) {
+		pq.up(h.index)
+	}
+}
+
+// Remove 删除句柄对应的元素并返回，句柄不在队列中时 panic，时间复杂度 O(log(pq.Len()))
+func (pq *IndexedPriorityQueue[T]) Remove(h *PQHandle[T]) T {
+	if !pq.Contains(h) {
+		panic("IndexedPriorityQueue.Remove: handle is not in the queue")
+	}
+	idx := h.index
+	n := len(pq.heap) - 1
+	if idx != n {
+		pq.swap(idx, n)
+		if !pq.down(idx, n) {
+			pq.up(idx)
+		}
+	}
+	pq.heap[n] = nil
+	pq.heap = pq.heap[:n]
+	h.index = -1
+	return h.value
+}
+
+func (pq *IndexedPriorityQueue[T]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.updateIndex(i, j)
+}
+
+func (pq *IndexedPriorityQueue[T]) up(j int) {
+	heap.SiftUpMinHeapFunc(pq.heap, j, pq.lessHandle, pq.updateIndex)
+}
+
+func (pq *IndexedPriorityQueue[T]) down(i0, n int) bool {
+	return heap.SiftDownMinHeapFunc(pq.heap, i0, n, pq.lessHandle, pq.updateIndex)
+}
+
+func (pq *IndexedPriorityQueue[T]) lessHandle(a, b *PQHandle[T]) bool {
+	return pq.impl.less(a.value, b.value)
+}
+
+// updateIndex 在堆中交换下标 i 与 j 的元素后更新它们的句柄
+func (pq *IndexedPriorityQueue[T]) updateIndex(i, j int) {
+	pq.heap[i].index = i
+	pq.heap[j].index = j
+}
+
+type ipqImpl[T any] interface {
+	less(a, b T) bool
+}
+
+type ipqOrdered[T gostl.Ordered] struct {
+	IndexedPriorityQueue[T]
+}
+
+func (q *ipqOrdered[T]) less(a, b T) bool {
+	return a < b
+}
+
+type ipqFunc[T any] struct {
+	IndexedPriorityQueue[T]
+	lessFunc gostl.LessFunc[T]
+}
+
+func (q *ipqFunc[T]) less(a, b T) bool {
+	return q.lessFunc(a, b)
+}
@@ -0,0 +1,140 @@
+package queue
+
+import "gostl"
+
+// MonotonicQueue 单调队列，维护一个滑动窗口内元素的最小值与最大值，
+// 每个元素带有单调不减的键（下标或时间戳），用于按键淘汰过期元素，
+// Push、PopFront、ExpireBefore 的均摊时间复杂度为 O(1)，Min、Max 的时间复杂度为 O(1)
+type MonotonicQueue[T any] struct {
+	keys    ArrayDeque[int64]             // 窗口内所有元素的键，按插入顺序
+	mins    ArrayDeque[monotonicEntry[T]] // 值单调递增，队首为最小值
+	maxs    ArrayDeque[monotonicEntry[T]] // 值单调递减，队首为最大值
+	headSeq uint64                        // 窗口内最早元素的序号
+	tailSeq uint64                        // 下一个插入元素的序号
+	impl    monotonicImpl[T]
+}
+
+type monotonicEntry[T any] struct {
+	seq   uint64
+	value T
+}
+
+// NewMonotonicQueue 构造一个空的可比较类型单调队列
+func NewMonotonicQueue[T gostl.Ordered]() *MonotonicQueue[T] {
+	q := monotonicOrdered[T]{}
+	q.impl = (monotonicImpl[T])(&q)
+	return &q.MonotonicQueue
+}
+
+// NewMonotonicQueueFunc 基于比较函数 less 构造一个空的单调队列
+func NewMonotonicQueueFunc[T any](less gostl.LessFunc[T]) *MonotonicQueue[T] {
+	q := monotonicFunc[T]{}
+	q.lessFunc = less
+	q.impl = (monotonicImpl[T])(&q)
+	return &q.MonotonicQueue
+}
+
+// Len 获取窗口内元素的数量
+func (q *MonotonicQueue[T]) Len() int {
+	return q.keys.Len()
+}
+
+// Empty 判断窗口是否为空
+func (q *MonotonicQueue[T]) Empty() bool {
+	return q.keys.Empty()
+}
+
+// Clear 清空窗口
+func (q *MonotonicQueue[T]) Clear() {
+	q.keys.Clear()
+	q.mins.Clear()
+	q.maxs.Clear()
+	q.headSeq = q.tailSeq
+}
+
+// Min 获取窗口内的最小值，若窗口为空则 panic
+func (q *MonotonicQueue[T]) Min() T {
+	if q.Empty() {
+		panic("MonotonicQueue.Min: empty queue")
+	}
+	return q.mins.Front().value
+}
+
+// Max 获取窗口内的最大值，若窗口为空则 panic
+func (q *MonotonicQueue[T]) Max() T {
+	if q.Empty() {
+		panic("MonotonicQueue.Max: empty queue")
+	}
+	return q.maxs.Front().value
+}
+
+// FrontKey 获取窗口内最早元素的键，若窗口为空则 panic
+func (q *MonotonicQueue[T]) FrontKey() int64 {
+	return q.keys.Front()
+}
+
+// Push 在窗口尾部插入键为 key 的元素，若 key 小于上一个插入元素的键则 panic
+func (q *MonotonicQueue[T]) Push(key int64, value T) {
+	if !q.keys.Empty() && key < q.keys.Back() {
+		panic("MonotonicQueue.Push: key is less than the previous key")
+	}
+	entry := monotonicEntry[T]{q.tailSeq, value}
+	q.tailSeq++
+	q.keys.PushBack(key)
+
+	// 被新元素支配的旧元素不可能再成为最值，直接丢弃；相等时保留较新的元素
+	for !q.mins.Empty() && !q.impl.less(q.mins.Back().value, value) {
+		q.mins.PopBack()
+	}
+	q.mins.PushBack(entry)
+	for !q.maxs.Empty() && !q.impl.less(value, q.maxs.Back().value) {
+		q.maxs.PopBack()
+	}
+	q.maxs.PushBack(entry)
+}
+
+// PopFront 删除窗口内最早的元素，若窗口为空则 panic
+func (q *MonotonicQueue[T]) PopFront() {
+	if q.Empty() {
+		panic("MonotonicQueue.PopFront: empty queue")
+	}
+	q.keys.PopFront()
+	q.headSeq++
+	if q.mins.Front().seq < q.headSeq {
+		q.mins.PopFront()
+	}
+	if q.maxs.Front().seq < q.headSeq {
+		q.maxs.PopFront()
+	}
+}
+
+// ExpireBefore 删除窗口内所有键小于 key 的元素，返回删除的元素数量
+func (q *MonotonicQueue[T]) ExpireBefore(key int64) int {
+	n := 0
+	for !q.keys.Empty() && q.keys.Front() < key {
+		q.PopFront()
+		n++
+	}
+	return n
+}
+
+type monotonicImpl[T any] interface {
+	less(a, b T) bool
+}
+
+type monotonicOrdered[T gostl.Ordered] struct {
+	MonotonicQueue[T]
+}
+
+func (q *monotonicOrdered[T]) less(a, b T) bool {
+	return a < b
+}
+
+type monotonicFunc[T any] struct {
+	MonotonicQueue[T]
+	lessFunc gostl.LessFunc[T]
+}
+
+func (q *monotonicFunc[T]) less(a, b T) bool {
+	return q.lessFunc(a, b)
+}
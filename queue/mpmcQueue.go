@@ -0,0 +1,181 @@
+package queue
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// cacheLinePad 填充到一个缓存行，避免被不同协程频繁修改的字段发生伪共享
+type cacheLinePad struct {
+	_ [64]byte
+}
+
+// MPMCQueue 无锁的有界多生产者多消费者队列，基于每个槽位的序号实现（Dmitry Vyukov 的设计），
+// 容量向上取整为 2 的幂，所有方法均不阻塞
+type MPMCQueue[T any] struct {
+	_          cacheLinePad
+	enqueuePos atomic.Uint64 // 下一个写入位置
+	_          cacheLinePad
+	dequeuePos atomic.Uint64 // 下一个读取位置
+	_          cacheLinePad
+	mask       uint64
+	slots      []mpmcSlot[T]
+}
+
+// mpmcSlot 槽位的序号 seq 等于 pos 时可写入位置 pos，等于 pos+1 时可读取位置 pos
+type mpmcSlot[T any] struct {
+	seq   atomic.Uint64
+	value T
+}
+
+// NewMPMCQueue 构造一个容量不小于 capacity 的队列，capacity 小于 1 时 panic
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	if capacity < 1 {
+		panic("queue.NewMPMCQueue: capacity must be positive")
+	}
+	n := ceilPowerOfTwo(uint64(capacity))
+	q := &MPMCQueue[T]{
+		mask:  n - 1,
+		slots: make([]mpmcSlot[T], n),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Cap 获取队列的容量
+func (q *MPMCQueue[T]) Cap() int {
+	return len(q.slots)
+}
+
+// Len 获取队列中元素数量的近似值，并发修改时仅供参考
+func (q *MPMCQueue[T]) Len() int {
+	dequeuePos := q.dequeuePos.Load()
+	enqueuePos := q.enqueuePos.Load()
+	if enqueuePos <= dequeuePos {
+		return 0
+	}
+	return int(min(enqueuePos-dequeuePos, uint64(len(q.slots))))
+}
+
+// TryEnqueue 尝试在队尾插入元素，若队列已满则返回 false
+func (q *MPMCQueue[T]) TryEnqueue(value T) bool {
+	pos := q.enqueuePos.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		diff := int64(slot.seq.Load() - pos)
+		switch {
+		case diff == 0:
+			if q.enqueuePos.CompareAndSwap(pos, pos+1) {
+				slot.value = value
+				slot.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.enqueuePos.Load()
+		case diff < 0: // 该槽位尚未被上一轮的消费者读取
+			return false
+		default: // 其他生产者已占用该位置
+			pos = q.enqueuePos.Load()
+		}
+	}
+}
+
+// TryDequeue 尝试删除并返回队首元素，若队列为空则返回 false
+func (q *MPMCQueue[T]) TryDequeue() (T, bool) {
+	pos := q.dequeuePos.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		diff := int64(slot.seq.Load() - (pos + 1))
+		switch {
+		case diff == 0:
+			if q.dequeuePos.CompareAndSwap(pos, pos+1) {
+				value := slot.value
+				var zero T
+				slot.value = zero
+				slot.seq.Store(pos + q.mask + 1)
+				return value, true
+			}
+			pos = q.dequeuePos.Load()
+		case diff < 0: // 该槽位尚未被写入
+			var zero T
+			return zero, false
+		default: // 其他消费者已读取该位置
+			pos = q.dequeuePos.Load()
+		}
+	}
+}
+
+// TryEnqueueBatch 尝试在队尾插入 values 中的元素，返回插入的元素数量，
+// 通过一次 CAS 占用连续的若干个可写槽位，因此插入的元素在队列中是连续的，
+// 队列剩余空间不足时只插入能放下的前缀
+func (q *MPMCQueue[T]) TryEnqueueBatch(values []T) int {
+	pos := q.enqueuePos.Load()
+	for len(values) > 0 {
+		n := q.readySlots(pos, 0, len(values))
+		if n == 0 {
+			if int64(q.slots[pos&q.mask].seq.Load()-pos) < 0 { // 队列已满
+				return 0
+			}
+			pos = q.enqueuePos.Load()
+			continue
+		}
+		if q.enqueuePos.CompareAndSwap(pos, pos+uint64(n)) {
+			for i := 0; i < n; i++ {
+				slot := &q.slots[(pos+uint64(i))&q.mask]
+				slot.value = values[i]
+				slot.seq.Store(pos + uint64(i) + 1)
+			}
+			return n
+		}
+		pos = q.enqueuePos.Load()
+	}
+	return 0
+}
+
+// TryDequeueBatch 尝试删除至多 len(dst) 个队首元素并按顺序写入 dst，返回删除的元素数量，
+// 通过一次 CAS 占用连续的若干个可读槽位
+func (q *MPMCQueue[T]) TryDequeueBatch(dst []T) int {
+	pos := q.dequeuePos.Load()
+	for len(dst) > 0 {
+		n := q.readySlots(pos, 1, len(dst))
+		if n == 0 {
+			if int64(q.slots[pos&q.mask].seq.Load()-(pos+1)) < 0 { // 队列为空
+				return 0
+			}
+			pos = q.dequeuePos.Load()
+			continue
+		}
+		if q.dequeuePos.CompareAndSwap(pos, pos+uint64(n)) {
+			var zero T
+			for i := 0; i < n; i++ {
+				slot := &q.slots[(pos+uint64(i))&q.mask]
+				dst[i] = slot.value
+				slot.value = zero
+				slot.seq.Store(pos + uint64(i) + q.mask + 1)
+			}
+			return n
+		}
+		pos = q.dequeuePos.Load()
+	}
+	return 0
+}
+
+// readySlots 返回从位置 pos 开始、序号等于位置加 offset 的连续槽位数量，至多为 limit，
+// offset 为 0 时统计可写入的槽位，为 1 时统计可读取的槽位；
+// 序号只会在占用对应位置之后改变，因此只要随后对 pos 的 CAS 成功，这些槽位就归调用方独占
+func (q *MPMCQueue[T]) readySlots(pos, offset uint64, limit int) int {
+	n := 0
+	for n < limit && q.slots[(pos+uint64(n))&q.mask].seq.Load() == pos+uint64(n)+offset {
+		n++
+	}
+	return n
+}
+
+// ceilPowerOfTwo 返回不小于 n 的最小的 2 的幂，n 为 0 时返回 1
+func ceilPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(n-1)
+}
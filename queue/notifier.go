@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// notifier 基于 channel 的广播通知，相当于可与 context 配合使用的条件变量，
+// wait 与 broadcast 均须在持有外部互斥锁时调用
+type notifier struct {
+	ch chan struct{}
+}
+
+// wait 返回一个在下一次 broadcast 时关闭的 channel，调用方应在释放锁后等待它
+func (n *notifier) wait() <-chan struct{} {
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+// broadcast 唤醒所有等待者
+func (n *notifier) broadcast() {
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
+
+// await 在持有 mu 时调用，释放 mu 等待直到 ready 返回 true，返回时仍持有 mu，若 ctx 被取消则返回 ctx.Err()，
+// ready 在持有 mu 时调用，修改其依赖的状态后须调用 broadcast
+func (n *notifier) await(ctx context.Context, mu *sync.Mutex, ready func() bool) error {
+	for !ready() {
+		wait := n.wait()
+		mu.Unlock()
+		select {
+		case <-wait:
+			mu.Lock()
+		case <-ctx.Done():
+			mu.Lock()
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package queue
+
+import (
+	"gostl/stack"
+	"sync"
+)
+
+// PersistentQueue 不可变的持久化先进先出队列（Okasaki 实时队列），
+// Push 与 Pop 返回新版本而不修改原版本，所有版本始终有效，最坏时间复杂度均为 O(1)，
+// 零值即为空队列，可安全地在多个协程间共享
+//
+//	队列由惰性求值的前端流 front、栈形式的后端 rear 与调度指针 schedule 组成，
+//	每次操作对 schedule 求值一步，保证 rear 比 front 长时 front 已被完全求值，从而将翻转的代价均摊到每一步
+type PersistentQueue[T any] struct {
+	front    *lazyStream[T]
+	rear     stack.PersistentStack[T]
+	schedule *lazyStream[T] // front 中尚未求值的后缀，长度等于 front 与 rear 的长度之差
+	size     int
+}
+
+// lazyStream 惰性求值并记忆结果的流，nil 表示空流
+type lazyStream[T any] struct {
+	once  sync.Once
+	thunk func() *streamCell[T]
+	cell  *streamCell[T] // 求值结果，nil 表示空流
+}
+
+type streamCell[T any] struct {
+	head T
+	tail *lazyStream[T]
+}
+
+// NewPersistentQueue 构造一个空 PersistentQueue[T]
+func NewPersistentQueue[T any]() PersistentQueue[T] {
+	return PersistentQueue[T]{}
+}
+
+// PersistentQueueInitializerList 构造一个 PersistentQueue[T] 并用 values 依次入队
+func PersistentQueueInitializerList[T any](values ...T) PersistentQueue[T] {
+	q := PersistentQueue[T]{}
+	for _, value := range values {
+		q = q.Push(value)
+	}
+	return q
+}
+
+// Len 返回队列的长度
+func (q PersistentQueue[T]) Len() int {
+	return q.size
+}
+
+// Empty 返回队列是否为空
+func (q PersistentQueue[T]) Empty() bool {
+	return q.size == 0
+}
+
+// Front 返回队首元素，若队列为空则 panic
+func (q PersistentQueue[T]) Front() T {
+	if q.size == 0 {
+		panic("PersistentQueue.Front: empty queue")
+	}
+	return q.front.force().head
+}
+
+// Push 返回在队尾插入 value 后的新队列，q 本身不变
+func (q PersistentQueue[T]) Push(value T) PersistentQueue[T] {
+	return makePersistentQueue(q.front, q.rear.Push(value), q.schedule, q.size+1)
+}
+
+// Pop 返回删除队首元素后的新队列，q 本身不变，若队列为空则 panic
+func (q PersistentQueue[T]) Pop() PersistentQueue[T] {
+	if q.size == 0 {
+		panic("PersistentQueue.Pop: empty queue")
+	}
+	return makePersistentQueue(q.front.force().tail, q.rear, q.schedule, q.size-1)
+}
+
+// Values 从队首到队尾返回所有元素，时间复杂度 O(n)
+func (q PersistentQueue[T]) Values() []T {
+	values := make([]T, 0, q.size)
+	q.ForEach(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
+
+// ForEach 从队首到队尾遍历队列，并为每个元素执行 f 函数
+func (q PersistentQueue[T]) ForEach(f func(value T)) {
+	q.ForEachIf(func(value T) bool {
+		f(value)
+		return true
+	})
+}
+
+// ForEachIf 从队首到队尾遍历队列，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (q PersistentQueue[T]) ForEachIf(f func(value T) bool) {
+	for cell := q.front.force(); cell != nil; cell = cell.tail.force() {
+		if !f(cell.head) {
+			return
+		}
+	}
+	rear := q.rear.Values()
+	for i := len(rear) - 1; i >= 0; i-- {
+		if !f(rear[i]) {
+			return
+		}
+	}
+}
+
+// makePersistentQueue 对 schedule 求值一步；若 schedule 已耗尽（此时 rear 比 front 长一个元素），
+// 则将 front 与翻转后的 rear 惰性地拼接为新的 front
+func makePersistentQueue[T any](front *lazyStream[T], rear stack.PersistentStack[T], schedule *lazyStream[T], size int) PersistentQueue[T] {
+	if cell := schedule.force(); cell != nil {
+		return PersistentQueue[T]{front, rear, cell.tail, size}
+	}
+	front = rotate(front, rear, nil)
+	return PersistentQueue[T]{front, stack.PersistentStack[T]{}, front, size}
+}
+
+// rotate 惰性地计算 front ++ reverse(rear) ++ acc，要求 rear 比 front 恰好长一个元素，每次求值只做 O(1) 的工作
+func rotate[T any](front *lazyStream[T], rear stack.PersistentStack[T], acc *lazyStream[T]) *lazyStream[T] {
+	return &lazyStream[T]{thunk: func() *streamCell[T] {
+		next := &lazyStream[T]{cell: &streamCell[T]{rear.Top(), acc}}
+		cell := front.force()
+		if cell == nil {
+			return next.cell
+		}
+		return &streamCell[T]{cell.head, rotate(cell.tail, rear.Pop(), next)}
+	}}
+}
+
+// force 求值并返回流的第一个单元，结果会被记忆，nil 表示空流
+func (s *lazyStream[T]) force() *streamCell[T] {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		if s.thunk != nil {
+			s.cell = s.thunk()
+			s.thunk = nil
+		}
+	})
+	return s.cell
+}
@@ -6,58 +6,125 @@ import (
 )
 
 type PriorityQueue[T any] struct {
-	heap []T
-	impl pqImpl[T]
+	heap  []T
+	arity int // 堆的叉数，默认为二叉堆
+	impl  pqImpl[T]
 }
 
-func NewPriorityQueue[T gostl.Ordered]() *PriorityQueue[T] {
+// PriorityQueueOption 优先队列的构造选项
+type PriorityQueueOption func(opts *pqOptions)
+
+type pqOptions struct {
+	arity  int
+	stable bool
+}
+
+// WithArity 指定优先队列底层堆的叉数，arity 小于 2 时 panic
+//
+//	叉数越大，Push 越快，Pop 越慢，插入远多于弹出时使用 4 叉堆通常优于二叉堆
+func WithArity(arity int) PriorityQueueOption {
+	if arity < 2 {
+		panic("queue.WithArity: arity must be at least 2")
+	}
+	return func(opts *pqOptions) {
+		opts.arity = arity
+	}
+}
+
+// WithStable 使优先队列稳定：相等的元素按插入顺序（先进先出）弹出，
+// 每个元素额外占用一个序号，适用于需要确定性调度的场景
+func WithStable() PriorityQueueOption {
+	return func(opts *pqOptions) {
+		opts.stable = true
+	}
+}
+
+func newPQOptions(opts []PriorityQueueOption) pqOptions {
+	o := pqOptions{arity: 2}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func NewPriorityQueue[T gostl.Ordered](opts ...PriorityQueueOption) *PriorityQueue[T] {
+	o := newPQOptions(opts)
+	if o.stable {
+		return newPQStable(func(a, b T) bool { return a < b }, o.arity, nil)
+	}
 	pq := pqOrdered[T]{}
+	pq.arity = o.arity
 	pq.impl = (pqImpl[T])(&pq)
 	return &pq.PriorityQueue
 }
 
-func NewPriorityQueueInitializerList[T gostl.Ordered](values ...T) *PriorityQueue[T] {
-	heap.NewMinHeap(&values)
+// NewPriorityQueueInitializerList 用 values 构造优先队列，时间复杂度 O(n)，之后不应再使用 values（非稳定模式下其底层数组被优先队列接管）
+func NewPriorityQueueInitializerList[T gostl.Ordered](values []T, opts ...PriorityQueueOption) *PriorityQueue[T] {
+	o := newPQOptions(opts)
+	if o.stable {
+		return newPQStable(func(a, b T) bool { return a < b }, o.arity, values)
+	}
+	if o.arity == 2 {
+		heap.NewMinHeap(&values)
+	} else {
+		heap.NewDaryMinHeap(&values, o.arity)
+	}
 	pq := pqOrdered[T]{}
 	pq.heap = values
+	pq.arity = o.arity
 	pq.impl = (pqImpl[T])(&pq)
 	return &pq.PriorityQueue
 }
 
-func NewPriorityQueueFunc[T any](less gostl.LessFunc[T]) *PriorityQueue[T] {
+func NewPriorityQueueFunc[T any](less gostl.LessFunc[T], opts ...PriorityQueueOption) *PriorityQueue[T] {
+	o := newPQOptions(opts)
+	if o.stable {
+		return newPQStable(less, o.arity, nil)
+	}
 	pq := pqFunc[T]{}
 	pq.less = less
+	pq.arity = o.arity
 	pq.impl = (pqImpl[T])(&pq)
 	return &pq.PriorityQueue
 }
 
-func NewPriorityQueueFuncInitializerList[T any](less gostl.LessFunc[T], values ...T) *PriorityQueue[T] {
-	heap.NewMinHeapFunc(&values, less)
+// NewPriorityQueueFuncInitializerList 用 values 构造优先队列，时间复杂度 O(n)，之后不应再使用 values（非稳定模式下其底层数组被优先队列接管）
+func NewPriorityQueueFuncInitializerList[T any](less gostl.LessFunc[T], values []T, opts ...PriorityQueueOption) *PriorityQueue[T] {
+	o := newPQOptions(opts)
+	if o.stable {
+		return newPQStable(less, o.arity, values)
+	}
+	if o.arity == 2 {
+		heap.NewMinHeapFunc(&values, less)
+	} else {
+		heap.NewDaryMinHeapFunc(&values, o.arity, less)
+	}
 	pq := pqFunc[T]{}
 	pq.less = less
 	pq.heap = values
+	pq.arity = o.arity
 	pq.impl = (pqImpl[T])(&pq)
 	return &pq.PriorityQueue
 }
 
 // Len 获取当前 priority queue 节点数
 func (pq *PriorityQueue[T]) Len() int {
-	return len(pq.heap)
+	return pq.impl.size()
 }
 
 // Empty 获取 priority queue 是否为空
 func (pq *PriorityQueue[T]) Empty() bool {
-	return len(pq.heap) == 0
+	return pq.impl.size() == 0
 }
 
 // Clear 清空当前 priority queue
 func (pq *PriorityQueue[T]) Clear() {
-	pq.heap = pq.heap[:0]
+	pq.impl.reset()
 }
 
 // Top 获取 priority queue 头部元素，若 priority queue 为空则 panic
 func (pq *PriorityQueue[T]) Top() T {
-	return pq.heap[0]
+	return pq.impl.peek()
 }
 
 // Push 向 priority queue 插入元素
@@ -70,9 +137,36 @@ func (pq *PriorityQueue[T]) Pop() T {
 	return pq.impl.Pop()
 }
 
+// Drain 按优先级顺序弹出并返回所有元素，之后 priority queue 为空，时间复杂度 O(n log n)
+//
+//	稳定模式下相等的元素按插入顺序排列，因此结果是确定的
+func (pq *PriorityQueue[T]) Drain() []T {
+	values := make([]T, 0, pq.Len())
+	for !pq.Empty() {
+		values = append(values, pq.impl.Pop())
+	}
+	return values
+}
+
+func (pq *PriorityQueue[T]) size() int {
+	return len(pq.heap)
+}
+
+func (pq *PriorityQueue[T]) peek() T {
+	return pq.heap[0]
+}
+
+func (pq *PriorityQueue[T]) reset() {
+	gostl.FillZero(pq.heap)
+	pq.heap = pq.heap[:0]
+}
+
 type pqImpl[T any] interface {
 	Push(value T)
 	Pop() T
+	size() int
+	peek() T
+	reset()
 }
 
 type pqOrdered[T gostl.Ordered] struct {
@@ -80,11 +174,18 @@ type pqOrdered[T gostl.Ordered] struct {
 }
 
 func (q *pqOrdered[T]) Push(value T) {
-	heap.PushMinHeap(&q.heap, value)
+	if q.arity == 2 {
+		heap.PushMinHeap(&q.heap, value)
+	} else {
+		heap.PushDaryMinHeap(&q.heap, value, q.arity)
+	}
 }
 
 func (q *pqOrdered[T]) Pop() T {
-	return heap.PopMinHeap(&q.heap)
+	if q.arity == 2 {
+		return heap.PopMinHeap(&q.heap)
+	}
+	return heap.PopDaryMinHeap(&q.heap, q.arity)
 }
 
 type pqFunc[T any] struct {
@@ -93,9 +194,91 @@ type pqFunc[T any] struct {
 }
 
 func (q *pqFunc[T]) Push(value T) {
-	heap.PushMinHeapFunc(&q.heap, value, q.less)
+	if q.arity == 2 {
+		heap.PushMinHeapFunc(&q.heap, value, q.less)
+	} else {
+		heap.PushDaryMinHeapFunc(&q.heap, value, q.arity, q.less)
+	}
 }
 
 func (q *pqFunc[T]) Pop() T {
-	return heap.PopMinHeapFunc(&q.heap, q.less)
+	if q.arity == 2 {
+		return heap.PopMinHeapFunc(&q.heap, q.less)
+	}
+	return heap.PopDaryMinHeapFunc(&q.heap, q.arity, q.less)
+}
+
+// pqStable 稳定模式的实现，堆中存放带插入序号的元素，相等的元素按序号先进先出
+type pqStable[T any] struct {
+	PriorityQueue[T]
+	entries []pqEntry[T]
+	seq     uint64 // 下一个插入元素的序号
+	less    gostl.LessFunc[T]
+}
+
+type pqEntry[T any] struct {
+	value T
+	seq   uint64
+}
+
+// newPQStable 构造稳定的优先队列，values 按下标顺序视为依次插入
+func newPQStable[T any](less gostl.LessFunc[T], arity int, values []T) *PriorityQueue[T] {
+	pq := pqStable[T]{}
+	pq.less = less
+	pq.arity = arity
+	if len(values) > 0 {
+		pq.entries = make([]pqEntry[T], len(values))
+		for i := range values {
+			pq.entries[i] = pqEntry[T]{values[i], uint64(i)}
+		}
+		pq.seq = uint64(len(values))
+		if arity == 2 {
+			heap.NewMinHeapFunc(&pq.entries, pq.entryLess)
+		} else {
+			heap.NewDaryMinHeapFunc(&pq.entries, arity, pq.entryLess)
+		}
+	}
+	pq.impl = (pqImpl[T])(&pq)
+	return &pq.PriorityQueue
+}
+
+func (q *pqStable[T]) entryLess(a, b pqEntry[T]) bool {
+	if q.less(a.value, b.value) {
+		return true
+	}
+	if q.less(b.value, a.value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (q *pqStable[T]) Push(value T) {
+	entry := pqEntry[T]{value, q.seq}
+	q.seq++
+	if q.arity == 2 {
+		heap.PushMinHeapFunc(&q.entries, entry, q.entryLess)
+	} else {
+		heap.PushDaryMinHeapFunc(&q.entries, entry, q.arity, q.entryLess)
+	}
+}
+
+func (q *pqStable[T]) Pop() T {
+	if q.arity == 2 {
+		return heap.PopMinHeapFunc(&q.entries, q.entryLess).value
+	}
+	return heap.PopDaryMinHeapFunc(&q.entries, q.arity, q.entryLess).value
+}
+
+func (q *pqStable[T]) size() int {
+	return len(q.entries)
+}
+
+func (q *pqStable[T]) peek() T {
+	return q.entries[0].value
+}
+
+func (q *pqStable[T]) reset() {
+	gostl.FillZero(q.entries)
+	q.entries = q.entries[:0]
+	q.seq = 0
 }
@@ -0,0 +1,1034 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_IndexedPriorityQueue(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	pq := NewIndexedPriorityQueue[int]()
+	ref := map[*PQHandle[int]]int{}
+	for i := 0; i < 2000; i++ {
+		switch rander.Intn(4) {
+		case 0, 1:
+			v := rander.Intn(1000)
+			ref[pq.Push(v)] = v
+		case 2:
+			for h := range ref {
+				v := rander.Intn(1000)
+				pq.Update(h, v)
+				ref[h] = v
+				break
+			}
+		case 3:
+			for h, v := range ref {
+				if got := pq.Remove(h); got != v || pq.Contains(h) {
+					t.Fatalf("Remove = %d, want %d", got, v)
+				}
+				delete(ref, h)
+				break
+			}
+		}
+		if pq.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", pq.Len(), len(ref))
+		}
+	}
+
+	values := make([]int, 0, len(ref))
+	for h, v := range ref {
+		if !pq.Contains(h) || h.Value() != v {
+			t.Fatal("Contains: handle lost")
+		}
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	for _, want := range values {
+		if got := pq.Pop(); got != want {
+			t.Fatalf("Pop() = %d, want %d", got, want)
+		}
+	}
+	if !pq.Empty() {
+		t.Fatal("Empty() = false")
+	}
+}
+
+func Test_PriorityQueueArity(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	for _, arity := range []int{2, 3, 4} {
+		pq := NewPriorityQueue[int](WithArity(arity))
+		pqf := NewPriorityQueueFunc[int](func(a, b int) bool { return a > b }, WithArity(arity))
+		values := make([]int, 500)
+		for i := range values {
+			values[i] = rander.Intn(100)
+			pq.Push(values[i])
+			pqf.Push(values[i])
+		}
+		pqi := NewPriorityQueueInitializerList(slices.Clone(values), WithArity(arity))
+		pqfi := NewPriorityQueueFuncInitializerList(func(a, b int) bool { return a > b }, slices.Clone(values), WithArity(arity))
+		sort.Ints(values)
+		for i := range values {
+			if got := pq.Pop(); got != values[i] {
+				t.Fatalf("arity %d: Pop() = %d, want %d", arity, got, values[i])
+			}
+			if got := pqi.Pop(); got != values[i] {
+				t.Fatalf("arity %d: InitializerList Pop() = %d, want %d", arity, got, values[i])
+			}
+			if got := pqf.Pop(); got != values[len(values)-1-i] {
+				t.Fatalf("arity %d: Pop() = %d, want %d", arity, got, values[len(values)-1-i])
+			}
+			if got := pqfi.Pop(); got != values[len(values)-1-i] {
+				t.Fatalf("arity %d: InitializerList Pop() = %d, want %d", arity, got, values[len(values)-1-i])
+			}
+		}
+	}
+}
+
+func Test_PriorityQueueStable(t *testing.T) {
+	type job struct{ priority, id int }
+	less := func(a, b job) bool { return a.priority < b.priority }
+	rander := rand.New(rand.NewSource(1))
+	for _, arity := range []int{2, 4} {
+		pq := NewPriorityQueueFunc(less, WithStable(), WithArity(arity))
+		jobs := make([]job, 500)
+		for i := range jobs {
+			jobs[i] = job{rander.Intn(10), i}
+			pq.Push(jobs[i])
+		}
+		if pq.Len() != len(jobs) || pq.Top().priority != 0 {
+			t.Fatalf("arity %d: Len() = %d, Top() = %v", arity, pq.Len(), pq.Top())
+		}
+		// 预填充的稳定队列视 values 为依次插入，之后 Push 的元素排在相等的预填充元素之后
+		pqi := NewPriorityQueueFuncInitializerList(less, slices.Clone(jobs[:250]), WithStable(), WithArity(arity))
+		for _, j := range jobs[250:] {
+			pqi.Push(j)
+		}
+		sort.SliceStable(jobs, func(i, j int) bool { return less(jobs[i], jobs[j]) })
+		got, goti := pq.Drain(), pqi.Drain()
+		for i := range jobs {
+			if got[i] != jobs[i] || goti[i] != jobs[i] {
+				t.Fatalf("arity %d: Drain()[%d] = %v, %v, want %v", arity, i, got[i], goti[i], jobs[i])
+			}
+		}
+		if !pq.Empty() {
+			t.Fatalf("arity %d: queue should be empty after Drain()", arity)
+		}
+	}
+
+	pq := NewPriorityQueue[int]()
+	for _, v := range []int{3, 1, 2} {
+		pq.Push(v)
+	}
+	if got := pq.Drain(); got[0] != 1 || got[1] != 2 || got[2] != 3 || pq.Len() != 0 {
+		t.Fatalf("Drain() = %v", got)
+	}
+}
+
+func benchmarkPriorityQueuePush(b *testing.B, arity int) {
+	pq := NewPriorityQueue[int](WithArity(arity))
+	for i := 0; i < b.N; i++ {
+		pq.Push(b.N - i)
+		if i%8 == 7 {
+			pq.Pop()
+		}
+	}
+}
+
+func Benchmark_PriorityQueueBinary(b *testing.B) {
+	benchmarkPriorityQueuePush(b, 2)
+}
+
+func Benchmark_PriorityQueue4ary(b *testing.B) {
+	benchmarkPriorityQueuePush(b, 4)
+}
+
+func Test_DoubleEndedPriorityQueue(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	pq := NewDoubleEndedPriorityQueueFunc[int](func(a, b int) bool { return a < b })
+	ref := []int{}
+	for i := 0; i < 3000; i++ {
+		if rander.Intn(3) > 0 || len(ref) == 0 {
+			v := rander.Intn(100)
+			pq.Push(v)
+			ref = append(ref, v)
+			sort.Ints(ref)
+		} else if rander.Intn(2) == 0 {
+			if pq.PeekMin() != ref[0] || pq.PopMin() != ref[0] {
+				t.Fatal("PopMin: wrong element")
+			}
+			ref = ref[1:]
+		} else {
+			if pq.PeekMax() != ref[len(ref)-1] || pq.PopMax() != ref[len(ref)-1] {
+				t.Fatal("PopMax: wrong element")
+			}
+			ref = ref[:len(ref)-1]
+		}
+		if pq.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", pq.Len(), len(ref))
+		}
+	}
+
+	pq.Clear()
+	for want, f := range map[string]func(){
+		"DoubleEndedPriorityQueue.PeekMin: empty queue": func() { pq.PeekMin() },
+		"DoubleEndedPriorityQueue.PeekMax: empty queue": func() { pq.PeekMax() },
+		"DoubleEndedPriorityQueue.PopMin: empty queue":  func() { pq.PopMin() },
+		"DoubleEndedPriorityQueue.PopMax: empty queue":  func() { pq.PopMax() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Fatalf("recover() = %v, want %q", r, want)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func Test_BlockingPriorityQueue(t *testing.T) {
+	q := NewBlockingPriorityQueue[int](4)
+	ctx := context.Background()
+
+	const producers, perProducer = 4, 250
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.Push(ctx, p*perProducer+i); err != nil {
+					t.Errorf("Push() = %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	for i := 0; i < len(seen); i++ {
+		v, err := q.Pop(ctx)
+		if err != nil || seen[v] {
+			t.Fatalf("Pop() = (%d, %v)", v, err)
+		}
+		seen[v] = true
+	}
+	wg.Wait()
+
+	for i := 0; i < q.Cap(); i++ {
+		q.TryPush(i)
+	}
+	if q.TryPush(100) {
+		t.Fatalf("TryPush() on a full queue should fail")
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Push(timeout, 100); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Push() on a full queue = %v", err)
+	}
+
+	q.Close()
+	if err := q.Push(ctx, 100); err != ErrQueueClosed {
+		t.Fatalf("Push() after Close() = %v", err)
+	}
+	for i := 0; i < q.Cap(); i++ {
+		if v, err := q.Pop(ctx); v != i || err != nil {
+			t.Fatalf("Pop() after Close() = (%d, %v), want %d", v, err, i)
+		}
+	}
+	if _, err := q.Pop(ctx); err != ErrQueueClosed {
+		t.Fatalf("Pop() on a closed empty queue = %v", err)
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Fatalf("TryPop() on an empty queue should fail")
+	}
+}
+
+func Test_DelayQueue(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	q := NewDelayQueue[int](clock)
+	for i, delay := range []int{30, 10, 20, 10} {
+		q.PushDelay(i, time.Duration(delay)*time.Second)
+	}
+	if _, ok := q.Poll(); ok {
+		t.Fatalf("Poll() before any deadline should fail")
+	}
+
+	clock.Advance(10 * time.Second)
+	for _, want := range []int{1, 3} {
+		if v, ok := q.Poll(); !ok || v != want {
+			t.Fatalf("Poll() = (%d, %v), want %d", v, ok, want)
+		}
+	}
+	if _, ok := q.Poll(); ok || q.Len() != 2 {
+		t.Fatalf("Poll() should fail with 2 pending elements")
+	}
+
+	ctx := context.Background()
+	done := make(chan int)
+	go func() {
+		v, _ := q.Take(ctx)
+		done <- v
+	}()
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(10 * time.Second)
+	if v := <-done; v != 2 {
+		t.Fatalf("Take() = %d, want 2", v)
+	}
+
+	// 被取消的 Take 需要停止它创建的定时器
+	cancelCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		for clock.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+	if _, err := q.Take(cancelCtx); err != context.Canceled {
+		t.Fatalf("Take() with a canceled context = %v", err)
+	}
+	if clock.Waiters() != 0 {
+		t.Fatalf("Take() left %d timers after cancellation", clock.Waiters())
+	}
+
+	q.Close()
+	if err := q.PushDelay(4, 0); err != ErrQueueClosed {
+		t.Fatalf("PushDelay() after Close() = %v", err)
+	}
+	if _, err := q.Take(ctx); err != ErrQueueClosed || clock.Waiters() != 0 {
+		t.Fatalf("Take() after Close() = %v with %d timers", err, clock.Waiters())
+	}
+	clock.Advance(10 * time.Second)
+	if v, err := q.Take(ctx); v != 0 || err != nil {
+		t.Fatalf("Take() of an expired element after Close() = (%d, %v)", v, err)
+	}
+}
+
+func Test_TimingWheel(t *testing.T) {
+	const tick = time.Millisecond
+	clock := NewManualClock(time.Unix(0, 0))
+	tw := NewTimingWheel(tick, 8, clock)
+	rander := rand.New(rand.NewSource(1))
+
+	type record struct {
+		due, fired time.Duration
+		timer      *Timer
+		stopped    bool
+	}
+	records := make([]*record, 2000)
+	for i := range records {
+		r := &record{due: time.Duration(rander.Intn(5000)) * tick / 4, fired: -1}
+		r.timer = tw.Schedule(r.due, func() { r.fired = clock.Now().Sub(time.Unix(0, 0)) })
+		records[i] = r
+	}
+	for _, r := range records[:500] {
+		r.stopped = r.timer.Stop()
+	}
+	if tw.Len() != 1500 {
+		t.Fatalf("Len() = %d, want 1500", tw.Len())
+	}
+
+	for step := 0; step < 2000; step++ {
+		clock.Advance(time.Duration(rander.Intn(3)) * tick)
+		tw.Advance()
+	}
+	clock.Advance(time.Hour)
+	tw.Advance()
+
+	for i, r := range records {
+		switch {
+		case r.stopped:
+			if r.fired >= 0 {
+				t.Fatalf("timer %d fired after Stop()", i)
+			}
+		case r.fired < r.due:
+			t.Fatalf("timer %d due at %v fired at %v", i, r.due, r.fired)
+		case r.timer.Stop():
+			t.Fatalf("Stop() of fired timer %d should fail", i)
+		}
+	}
+	if tw.Len() != 0 {
+		t.Fatalf("Len() = %d after all timers fired", tw.Len())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fired := make(chan struct{})
+	tw.Schedule(5*tick, func() { close(fired) })
+	stopped := make(chan error)
+	go func() { stopped <- tw.Run(ctx) }()
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(5 * tick)
+	<-fired
+	tw.Schedule(5*tick, func() {})
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	// Run 返回前需要停止它创建的定时器
+	if err := <-stopped; err != context.Canceled || clock.Waiters() != 0 {
+		t.Fatalf("Run() = %v with %d timers after cancellation", err, clock.Waiters())
+	}
+}
+
+func Test_ArrayDeque(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	q := NewArrayDequeWithCapacity[int](3)
+	var ref []int
+	for i := 0; i < 5000; i++ {
+		switch op := rander.Intn(7); {
+		case op == 0 || op == 1:
+			q.PushBack(i)
+			ref = append(ref, i)
+		case op == 2 || op == 3:
+			q.PushFront(i)
+			ref = append([]int{i}, ref...)
+		case op == 4 && len(ref) > 0:
+			if got := q.PopFront(); got != ref[0] {
+				t.Fatalf("PopFront() = %d, want %d", got, ref[0])
+			}
+			ref = ref[1:]
+		case op == 5 && len(ref) > 0:
+			if got := q.PopBack(); got != ref[len(ref)-1] {
+				t.Fatalf("PopBack() = %d, want %d", got, ref[len(ref)-1])
+			}
+			ref = ref[:len(ref)-1]
+		case op == 6 && len(ref) > 0:
+			idx := rander.Intn(len(ref))
+			q.Set(idx, -i)
+			ref[idx] = -i
+			if rander.Intn(10) == 0 {
+				q.Shrink()
+			}
+		}
+		if q.Len() != len(ref) || len(ref) > 0 && (q.Front() != ref[0] || q.Back() != ref[len(ref)-1]) {
+			t.Fatalf("step %d: Len() = %d, want %d", i, q.Len(), len(ref))
+		}
+	}
+
+	values := q.Values()
+	for i := range ref {
+		if q.At(i) != ref[i] || values[i] != ref[i] {
+			t.Fatalf("At(%d) = %d, want %d", i, q.At(i), ref[i])
+		}
+	}
+	i := 0
+	q.ForEachIf(func(value *int) bool {
+		if *value != ref[i] {
+			t.Fatalf("ForEachIf: [%d] = %d, want %d", i, *value, ref[i])
+		}
+		i++
+		return i < 10
+	})
+	q.Reserve(q.Cap() * 2)
+	if q.Values()[0] != ref[0] || q.Cap() < 2*len(ref) {
+		t.Fatalf("Reserve() lost elements")
+	}
+	q.Clear()
+	if !q.Empty() || q.Cap() == 0 {
+		t.Fatalf("Clear() = Len %d, Cap %d", q.Len(), q.Cap())
+	}
+}
+
+func Test_BlockingQueue(t *testing.T) {
+	q := NewBlockingQueue[int](8)
+	ctx := context.Background()
+
+	const n = 2000
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := q.Put(ctx, i); err != nil {
+				t.Errorf("Put() = %v", err)
+				return
+			}
+		}
+	}()
+	var drained []int
+	for len(drained) < n {
+		if v, err := q.Take(ctx); err != nil || v != len(drained) {
+			t.Fatalf("Take() = (%d, %v), want %d", v, err, len(drained))
+		}
+		drained = append(drained, len(drained))
+	}
+
+	for i := 0; i < q.Cap(); i++ {
+		q.Put(ctx, i)
+	}
+	if q.Offer(100, time.Millisecond) {
+		t.Fatalf("Offer() on a full queue should fail")
+	}
+	if v, ok := q.Peek(); !ok || v != 0 {
+		t.Fatalf("Peek() = (%d, %v)", v, ok)
+	}
+	var dst []int
+	if got := q.DrainTo(&dst, 3); got != 3 || dst[2] != 2 || q.Len() != 5 {
+		t.Fatalf("DrainTo() = %d, %v", got, dst)
+	}
+	q.Resize(4)
+	if q.Offer(100, 0) {
+		t.Fatalf("Offer() after shrinking below Len() should fail")
+	}
+	if got := q.DrainTo(&dst, 0); got != 5 || len(dst) != 8 || dst[7] != 7 {
+		t.Fatalf("DrainTo() = %d, %v", got, dst)
+	}
+	if _, ok := q.Poll(time.Millisecond); ok {
+		t.Fatalf("Poll() on an empty queue should fail")
+	}
+
+	q.Put(ctx, 1)
+	q.Close()
+	if err := q.Put(ctx, 2); err != ErrQueueClosed {
+		t.Fatalf("Put() after Close() = %v", err)
+	}
+	if v, err := q.Take(ctx); v != 1 || err != nil {
+		t.Fatalf("Take() after Close() = (%d, %v)", v, err)
+	}
+	if _, err := q.Take(ctx); err != ErrQueueClosed {
+		t.Fatalf("Take() on a closed empty queue = %v", err)
+	}
+}
+
+func Test_MPMCQueue(t *testing.T) {
+	q := NewMPMCQueue[int](100)
+	if q.Cap() != 128 {
+		t.Fatalf("Cap() = %d, want 128", q.Cap())
+	}
+
+	const producers, consumers, perProducer = 4, 4, 5000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			batch := make([]int, 0, 8)
+			for i := 0; i < perProducer; i++ {
+				batch = append(batch, p*perProducer+i)
+				if len(batch) == cap(batch) || i == perProducer-1 {
+					for len(batch) > 0 {
+						n := q.TryEnqueueBatch(batch)
+						if n == 0 {
+							runtime.Gosched()
+						}
+						batch = batch[n:]
+					}
+					batch = batch[:0]
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make([]bool, producers*perProducer)
+	var received atomic.Int64
+	var consumersWg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumersWg.Add(1)
+		go func() {
+			defer consumersWg.Done()
+			buf := make([]int, 4)
+			last := make([]int, producers) // 每个生产者的元素应按顺序到达
+			for i := range last {
+				last[i] = -1
+			}
+			for received.Load() < producers*perProducer {
+				n := q.TryDequeueBatch(buf)
+				if n == 0 {
+					runtime.Gosched()
+					continue
+				}
+				mu.Lock()
+				for _, v := range buf[:n] {
+					if seen[v] || v%perProducer <= last[v/perProducer] {
+						t.Errorf("duplicate or out-of-order value %d", v)
+					}
+					seen[v] = true
+					last[v/perProducer] = v % perProducer
+				}
+				mu.Unlock()
+				received.Add(int64(n))
+			}
+		}()
+	}
+	wg.Wait()
+	consumersWg.Wait()
+	if _, ok := q.TryDequeue(); ok || q.Len() != 0 {
+		t.Fatalf("queue should be empty")
+	}
+
+	// 批量插入只能放下前缀，批量删除在队列变空时停止
+	small := NewMPMCQueue[int](4)
+	if n := small.TryEnqueueBatch([]int{0, 1, 2, 3, 4, 5}); n != 4 || small.TryEnqueueBatch([]int{4}) != 0 {
+		t.Fatalf("TryEnqueueBatch() on a queue of capacity 4 = %d", n)
+	}
+	buf := make([]int, 8)
+	if n := small.TryDequeueBatch(buf); n != 4 || buf[0] != 0 || buf[3] != 3 || small.TryDequeueBatch(buf) != 0 {
+		t.Fatalf("TryDequeueBatch() = %d, %v", n, buf)
+	}
+
+	// 同一批插入的元素在队列中是连续的
+	const batches, batchSize = 100, 8
+	q = NewMPMCQueue[int](producers * batches * batchSize)
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			batch := make([]int, batchSize)
+			for b := 0; b < batches; b++ {
+				for i := range batch {
+					batch[i] = (p*batches+b)*batchSize + i
+				}
+				if q.TryEnqueueBatch(batch) != batchSize {
+					t.Errorf("TryEnqueueBatch() should not fail on a large queue")
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	for i := 0; i < producers*batches; i++ {
+		first, _ := q.TryDequeue()
+		for j := 1; j < batchSize; j++ {
+			if v, ok := q.TryDequeue(); !ok || v != first+j {
+				t.Fatalf("batch starting at %d is interleaved with %d", first, v)
+			}
+		}
+	}
+}
+
+func Test_SPSCRing(t *testing.T) {
+	q := NewSPSCRing[int](5)
+	if q.Cap() != 8 {
+		t.Fatalf("Cap() = %d, want 8", q.Cap())
+	}
+
+	const n = 20000
+	go func() {
+		batch := make([]int, 0, 3)
+		for i := 0; i < n; {
+			if i%2 == 0 {
+				if q.TryEnqueue(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+				continue
+			}
+			batch = batch[:0]
+			for j := i; j < min(i+3, n); j++ {
+				batch = append(batch, j)
+			}
+			k := q.TryEnqueueBatch(batch)
+			if k == 0 {
+				runtime.Gosched()
+			}
+			i += k
+		}
+	}()
+
+	buf := make([]int, 5)
+	for next := 0; next < n; {
+		if next%3 == 0 {
+			if v, ok := q.TryDequeue(); ok {
+				if v != next {
+					t.Fatalf("TryDequeue() = %d, want %d", v, next)
+				}
+				next++
+			} else {
+				runtime.Gosched()
+			}
+			continue
+		}
+		k := q.TryDequeueBatch(buf)
+		if k == 0 {
+			runtime.Gosched()
+		}
+		for _, v := range buf[:k] {
+			if v != next {
+				t.Fatalf("TryDequeueBatch() = %d, want %d", v, next)
+			}
+			next++
+		}
+	}
+	if _, ok := q.TryDequeue(); ok {
+		t.Fatalf("ring should be empty")
+	}
+}
+
+func Benchmark_MPMCQueue(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.TryEnqueue(1)
+			q.TryDequeue()
+		}
+	})
+}
+
+func Test_WorkStealingDeque(t *testing.T) {
+	q := NewWorkStealingDeque[int](2)
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+	if v, ok := q.Pop(); !ok || v != 9 {
+		t.Fatalf("Pop() = (%d, %v), want 9", v, ok)
+	}
+	if v, ok := q.Steal(); !ok || v != 0 {
+		t.Fatalf("Steal() = (%d, %v), want 0", v, ok)
+	}
+	for q.Len() > 0 {
+		q.Pop()
+	}
+	if _, ok := q.Pop(); ok {
+		t.Fatalf("Pop() on an empty deque should fail")
+	}
+
+	const n, thieves = 50000, 3
+	taken := make([]atomic.Int32, n)
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !done.Load() || q.Len() > 0 {
+				if v, ok := q.Steal(); ok {
+					taken[v].Add(1)
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		q.Push(i)
+		if i%3 == 0 {
+			if v, ok := q.Pop(); ok {
+				taken[v].Add(1)
+			}
+		}
+	}
+	for {
+		v, ok := q.Pop()
+		if !ok {
+			break
+		}
+		taken[v].Add(1)
+	}
+	done.Store(true)
+	wg.Wait()
+	for i := range taken {
+		if c := taken[i].Load(); c != 1 {
+			t.Fatalf("value %d taken %d times", i, c)
+		}
+	}
+}
+
+func Test_MonotonicQueue(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	q := NewMonotonicQueue[int]()
+	qf := NewMonotonicQueueFunc[int](func(a, b int) bool { return a > b })
+	type item struct {
+		key   int64
+		value int
+	}
+	var window []item
+	key := int64(0)
+	for i := 0; i < 5000; i++ {
+		key += int64(rander.Intn(3))
+		v := rander.Intn(50)
+		q.Push(key, v)
+		qf.Push(key, v)
+		window = append(window, item{key, v})
+
+		switch rander.Intn(4) {
+		case 0:
+			expire := key - int64(rander.Intn(20))
+			n := 0
+			for n < len(window) && window[n].key < expire {
+				n++
+			}
+			window = window[n:]
+			if got := q.ExpireBefore(expire); got != n {
+				t.Fatalf("ExpireBefore(%d) = %d, want %d", expire, got, n)
+			}
+			qf.ExpireBefore(expire)
+		case 1:
+			window = window[1:]
+			q.PopFront()
+			qf.PopFront()
+		}
+
+		if q.Len() != len(window) {
+			t.Fatalf("Len() = %d, want %d", q.Len(), len(window))
+		}
+		if len(window) == 0 {
+			continue
+		}
+		lo, hi := window[0].value, window[0].value
+		for _, it := range window {
+			lo, hi = min(lo, it.value), max(hi, it.value)
+		}
+		if q.Min() != lo || q.Max() != hi || qf.Min() != hi || qf.Max() != lo || q.FrontKey() != window[0].key {
+			t.Fatalf("step %d: Min() = %d, Max() = %d, want %d, %d", i, q.Min(), q.Max(), lo, hi)
+		}
+	}
+	q.Clear()
+	if !q.Empty() {
+		t.Fatalf("Clear() should empty the queue")
+	}
+	q.Push(key, 1)
+	if q.Min() != 1 || q.Max() != 1 {
+		t.Fatalf("Min()/Max() after Clear() = %d, %d", q.Min(), q.Max())
+	}
+}
+
+func Test_DiskQueue(t *testing.T) {
+	type event struct {
+		ID   int
+		Name string
+	}
+	dir := t.TempDir()
+	open := func() *DiskQueue[event] {
+		q, err := OpenDiskQueue[event](dir, JSONCodec[event]{}, WithSegmentSize(256))
+		if err != nil {
+			t.Fatalf("OpenDiskQueue() = %v", err)
+		}
+		return q
+	}
+	pop := func(q *DiskQueue[event], want int) {
+		t.Helper()
+		if e, err := q.Pop(); err != nil || e.ID != want {
+			t.Fatalf("Pop() = (%v, %v), want %d", e, err, want)
+		}
+	}
+
+	q := open()
+	for i := 0; i < 100; i++ {
+		if err := q.Push(event{i, "event"}); err != nil {
+			t.Fatalf("Push() = %v", err)
+		}
+	}
+	for i := 0; i < 30; i++ {
+		pop(q, i)
+	}
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+	for i := 30; i < 40; i++ {
+		pop(q, i)
+	}
+	if err := q.Rollback(); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	pop(q, 30)
+	q.Close()
+	if _, err := q.Pop(); err != ErrQueueClosed {
+		t.Fatalf("Pop() after Close() = %v", err)
+	}
+
+	// 重新打开后从已提交的位置继续，未提交的读取被重放
+	q = open()
+	if q.Len() != 70 {
+		t.Fatalf("Len() after reopen = %d, want 70", q.Len())
+	}
+	for i := 30; i < 60; i++ {
+		pop(q, i)
+	}
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+	q.Close()
+	segments, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+
+	// 模拟崩溃时写了一半的记录
+	last := segments[len(segments)-1]
+	file, _ := os.OpenFile(last, os.O_WRONLY|os.O_APPEND, 0o644)
+	file.Write([]byte{100, 0, 0, 0, 1, 2})
+	file.Close()
+
+	q = open()
+	defer q.Close()
+	if q.Len() != 40 {
+		t.Fatalf("Len() after torn write = %d, want 40", q.Len())
+	}
+	q.Push(event{100, "after crash"})
+	for i := 60; i < 100; i++ {
+		pop(q, i)
+	}
+	if e, err := q.Peek(); err != nil || e.Name != "after crash" {
+		t.Fatalf("Peek() = (%v, %v)", e, err)
+	}
+	pop(q, 100)
+	if _, err := q.Pop(); err != ErrQueueEmpty {
+		t.Fatalf("Pop() on an empty queue = %v", err)
+	}
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+	if segments, _ := filepath.Glob(filepath.Join(dir, "*.seg")); len(segments) != 1 {
+		t.Fatalf("Commit() should remove consumed segments, got %v", segments)
+	}
+}
+
+func Test_DiskQueueCorruption(t *testing.T) {
+	dir := t.TempDir()
+	// 每条记录 9 字节（8 字节头部与 1 字节数据），每个分段 7 条记录
+	q, err := OpenDiskQueue[int](dir, JSONCodec[int]{}, WithSegmentSize(64))
+	if err != nil {
+		t.Fatalf("OpenDiskQueue() = %v", err)
+	}
+	defer q.Close()
+	if _, err := OpenDiskQueue[int](dir, JSONCodec[int]{}); err != ErrQueueLocked && runtime.GOOS != "windows" {
+		t.Fatalf("OpenDiskQueue() of a locked directory = %v", err)
+	}
+	for i := 0; i < 21; i++ {
+		q.Push(i % 10)
+	}
+	segments, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if len(segments) != 3 {
+		t.Fatalf("%d segments, want 3", len(segments))
+	}
+	corrupt := func(segment string, off int64, data ...byte) {
+		file, _ := os.OpenFile(segment, os.O_WRONLY, 0o644)
+		file.WriteAt(data, off)
+		file.Close()
+	}
+	pop := func(want int) {
+		t.Helper()
+		if v, err := q.Pop(); err != nil || v != want {
+			t.Fatalf("Pop() = (%d, %v), want %d", v, err, want)
+		}
+	}
+	popCorrupted := func() {
+		t.Helper()
+		var corruptionErr *CorruptionError
+		if _, err := q.Pop(); !errors.As(err, &corruptionErr) {
+			t.Fatalf("Pop() = %v, want *CorruptionError", err)
+		}
+	}
+
+	// 数据损坏但长度可信：只跳过这条记录
+	corrupt(segments[0], 2*9+8, 'x')
+	pop(0)
+	pop(1)
+	popCorrupted()
+	pop(3)
+
+	// 分段丢失：跳到下一个存在的分段
+	os.Remove(segments[1])
+	pop(4)
+	pop(5)
+	pop(6)
+	popCorrupted()
+	if q.Len() != 7 {
+		t.Fatalf("Len() after a missing segment = %d, want 7", q.Len())
+	}
+	pop(4)
+
+	// 长度字段损坏：放弃所在分段的剩余部分
+	corrupt(segments[2], 2*9, 0xff, 0xff, 0, 0)
+	pop(5)
+	popCorrupted()
+	if _, err := q.Pop(); err != ErrQueueEmpty {
+		t.Fatalf("Pop() after skipping the write segment = %v", err)
+	}
+	q.Push(7)
+	pop(7)
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+}
+
+// negativeRejectingCodec 无法解码负数的编解码器，用于模拟解码失败
+type negativeRejectingCodec struct {
+	JSONCodec[int]
+}
+
+func (c negativeRejectingCodec) Decode(data []byte) (int, error) {
+	value, err := c.JSONCodec.Decode(data)
+	if err == nil && value < 0 {
+		return 0, errors.New("negative value")
+	}
+	return value, err
+}
+
+func Test_DiskQueueDecodeError(t *testing.T) {
+	q, err := OpenDiskQueue[int](t.TempDir(), negativeRejectingCodec{})
+	if err != nil {
+		t.Fatalf("OpenDiskQueue() = %v", err)
+	}
+	defer q.Close()
+	for _, value := range []int{1, -1, 2} {
+		if err := q.Push(value); err != nil {
+			t.Fatalf("Push() = %v", err)
+		}
+	}
+	if value, err := q.Pop(); err != nil || value != 1 {
+		t.Fatalf("Pop() = (%d, %v), want 1", value, err)
+	}
+	var decodeErr *DecodeError
+	if _, err := q.Peek(); !errors.As(err, &decodeErr) || q.Len() != 2 {
+		t.Fatalf("Peek() = %v, Len() = %d, want *DecodeError and 2", err, q.Len())
+	}
+	if _, err := q.Pop(); !errors.As(err, &decodeErr) {
+		t.Fatalf("Pop() = %v, want *DecodeError", err)
+	}
+	if value, err := q.Pop(); err != nil || value != 2 {
+		t.Fatalf("Pop() after a decode error = (%d, %v), want 2", value, err)
+	}
+	if err := q.Rollback(); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	if q.Len() != 3 {
+		t.Fatalf("Len() after Rollback() = %d, want 3", q.Len())
+	}
+}
+
+func Test_PersistentQueue(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	type version struct {
+		q   PersistentQueue[int]
+		ref []int
+	}
+	versions := []version{{NewPersistentQueue[int](), nil}}
+	for i := 0; i < 3000; i++ {
+		v := versions[rander.Intn(len(versions))]
+		var next version
+		if len(v.ref) == 0 || rander.Intn(3) > 0 {
+			next = version{v.q.Push(i), append(append([]int{}, v.ref...), i)}
+		} else {
+			if v.q.Front() != v.ref[0] {
+				t.Fatalf("Front() = %d, want %d", v.q.Front(), v.ref[0])
+			}
+			next = version{v.q.Pop(), v.ref[1:]}
+		}
+		versions = append(versions, next)
+	}
+	for _, v := range versions {
+		got := v.q.Values()
+		if v.q.Len() != len(v.ref) || len(got) != len(v.ref) {
+			t.Fatalf("Len() = %d, want %d", v.q.Len(), len(v.ref))
+		}
+		for i := range got {
+			if got[i] != v.ref[i] {
+				t.Fatalf("Values()[%d] = %d, want %d", i, got[i], v.ref[i])
+			}
+		}
+	}
+	q := PersistentQueueInitializerList(1, 2, 3)
+	if q.Pop().Pop().Front() != 3 || q.Front() != 1 {
+		t.Fatalf("PersistentQueueInitializerList: unexpected order %v", q.Values())
+	}
+}
@@ -0,0 +1,115 @@
+package queue
+
+import "sync/atomic"
+
+// SPSCRing 无锁的有界单生产者单消费者环形队列，容量向上取整为 2 的幂，所有方法均不阻塞
+//
+//	同一时刻只能有一个协程调用 TryEnqueue/TryEnqueueBatch，且只能有一个协程调用 TryDequeue/TryDequeueBatch，
+//	批量方法只发布一次位置，开销远小于逐个调用
+type SPSCRing[T any] struct {
+	_          cacheLinePad
+	tail       atomic.Uint64 // 下一个写入位置，仅由生产者修改
+	cachedHead uint64        // 生产者缓存的 head，仅在缓存显示队列已满时才重新读取
+	_          cacheLinePad
+	head       atomic.Uint64 // 下一个读取位置，仅由消费者修改
+	cachedTail uint64        // 消费者缓存的 tail，仅在缓存显示队列为空时才重新读取
+	_          cacheLinePad
+	mask       uint64
+	buf        []T
+}
+
+// NewSPSCRing 构造一个容量不小于 capacity 的环形队列，capacity 小于 1 时 panic
+func NewSPSCRing[T any](capacity int) *SPSCRing[T] {
+	if capacity < 1 {
+		panic("queue.NewSPSCRing: capacity must be positive")
+	}
+	n := ceilPowerOfTwo(uint64(capacity))
+	return &SPSCRing[T]{
+		mask: n - 1,
+		buf:  make([]T, n),
+	}
+}
+
+// Cap 获取环形队列的容量
+func (q *SPSCRing[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Len 获取环形队列中元素数量的近似值，并发修改时仅供参考
+func (q *SPSCRing[T]) Len() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail <= head {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// TryEnqueue 尝试在队尾插入元素，若队列已满则返回 false，只能由生产者调用
+func (q *SPSCRing[T]) TryEnqueue(value T) bool {
+	tail := q.tail.Load()
+	if tail-q.cachedHead == uint64(len(q.buf)) {
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead == uint64(len(q.buf)) {
+			return false
+		}
+	}
+	q.buf[tail&q.mask] = value
+	q.tail.Store(tail + 1)
+	return true
+}
+
+// TryEnqueueBatch 按顺序插入 values 中尽可能多的元素，返回插入的元素数量，只能由生产者调用
+func (q *SPSCRing[T]) TryEnqueueBatch(values []T) int {
+	tail := q.tail.Load()
+	free := uint64(len(q.buf)) - (tail - q.cachedHead)
+	if free < uint64(len(values)) {
+		q.cachedHead = q.head.Load()
+		free = uint64(len(q.buf)) - (tail - q.cachedHead)
+	}
+	n := min(free, uint64(len(values)))
+	for i := uint64(0); i < n; i++ {
+		q.buf[(tail+i)&q.mask] = values[i]
+	}
+	if n > 0 {
+		q.tail.Store(tail + n)
+	}
+	return int(n)
+}
+
+// TryDequeue 尝试删除并返回队首元素，若队列为空则返回 false，只能由消费者调用
+func (q *SPSCRing[T]) TryDequeue() (T, bool) {
+	head := q.head.Load()
+	if head == q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head == q.cachedTail {
+			var zero T
+			return zero, false
+		}
+	}
+	var zero T
+	slot := &q.buf[head&q.mask]
+	value := *slot
+	*slot = zero
+	q.head.Store(head + 1)
+	return value, true
+}
+
+// TryDequeueBatch 删除至多 len(dst) 个队首元素并按顺序写入 dst，返回删除的元素数量，只能由消费者调用
+func (q *SPSCRing[T]) TryDequeueBatch(dst []T) int {
+	head := q.head.Load()
+	if q.cachedTail-head < uint64(len(dst)) {
+		q.cachedTail = q.tail.Load()
+	}
+	n := min(q.cachedTail-head, uint64(len(dst)))
+	var zero T
+	for i := uint64(0); i < n; i++ {
+		slot := &q.buf[(head+i)&q.mask]
+		dst[i] = *slot
+		*slot = zero
+	}
+	if n > 0 {
+		q.head.Store(head + n)
+	}
+	return int(n)
+}
@@ -0,0 +1,262 @@
+package queue
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// TimingWheel 分层时间轮，适用于大量定时器的场景，Schedule 与 Timer.Stop 的时间复杂度为 O(1)，
+// 定时器的精度为 tick：定时器不会早于到期时间触发，但可能最多晚一个 tick
+//
+//	每层时间轮有 wheelSize 个槽位，超出当前层范围的定时器放入上一层（每格跨度为下层的一整圈），
+//	随着时间推进逐层降级；推进时从各层的当前槽位向后查找下一个非空槽位，跳过空闲的时间段，
+//	查找的代价 O(层数 × wheelSize) 由 Advance 与 Run 承担，不在 Schedule 的路径上
+type TimingWheel struct {
+	mu        sync.Mutex
+	clock     Clock
+	wheel     *timingWheelLevel
+	size      int      // 尚未触发且未被取消的定时器数量
+	next      int64    // Run 正在等待的槽位到期时间，更早的槽位变为非空时广播
+	changed   notifier // 出现比 next 更早到期的槽位时广播
+	startTime time.Time
+}
+
+// Timer 时间轮中的定时器，由 TimingWheel.Schedule 返回
+type Timer struct {
+	expiration int64 // 到期时间，单位为相对于 startTime 的纳秒
+	f          func()
+	tw         *TimingWheel
+	bucket     *timerBucket // 所在槽位，已触发或已取消时为 nil
+	prev, next *Timer
+}
+
+// timerBucket 时间轮的槽位，为带哨兵的双向循环链表
+type timerBucket struct {
+	root  Timer
+	level *timingWheelLevel // 槽位所在的层
+}
+
+type timingWheelLevel struct {
+	tick        int64 // 每格的跨度
+	interval    int64 // 整圈的跨度
+	currentTime int64 // 当前时间，为 tick 的整数倍
+	count       int   // 本层槽位中的定时器数量，为 0 时查找下一个非空槽位可跳过本层
+	buckets     []*timerBucket
+	overflow    *timingWheelLevel // 上一层时间轮，按需创建
+}
+
+// NewTimingWheel 构造一个每格跨度为 tick、每层 wheelSize 个槽位的时间轮，clock 为 nil 时使用 SystemClock，
+// tick 不为正或 wheelSize 小于 2 时 panic
+func NewTimingWheel(tick time.Duration, wheelSize int, clock Clock) *TimingWheel {
+	if tick <= 0 || wheelSize < 2 {
+		panic("queue.NewTimingWheel: tick must be positive and wheelSize must be at least 2")
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &TimingWheel{
+		clock:     clock,
+		wheel:     newTimingWheelLevel(int64(tick), wheelSize, 0),
+		next:      math.MaxInt64,
+		startTime: clock.Now(),
+	}
+}
+
+func newTimingWheelLevel(tick int64, wheelSize int, startTime int64) *timingWheelLevel {
+	l := &timingWheelLevel{
+		tick:        tick,
+		interval:    tick * int64(wheelSize),
+		currentTime: startTime - startTime%tick,
+		buckets:     make([]*timerBucket, wheelSize),
+	}
+	for i := range l.buckets {
+		l.buckets[i] = newTimerBucket(l)
+	}
+	return l
+}
+
+// Len 获取尚未触发且未被取消的定时器数量
+func (tw *TimingWheel) Len() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.size
+}
+
+// Schedule 注册一个在 delay 时间后执行 f 的定时器，f 在 Advance 或 Run 的调用方协程中执行，
+// 到期时间向上取整到 tick 的整数倍，时间复杂度 O(1)
+func (tw *TimingWheel) Schedule(delay time.Duration, f func()) *Timer {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tick := tw.wheel.tick
+	expiration := (int64(tw.clock.Now().Sub(tw.startTime)+delay) + tick - 1) / tick * tick // 向上取整到 tick 的整数倍
+	t := &Timer{expiration: max(expiration, tw.wheel.currentTime+tick), f: f, tw: tw}
+	tw.add(t)
+	tw.size++
+	return t
+}
+
+// Stop 取消定时器，若定时器已触发或已被取消则返回 false，时间复杂度 O(1)
+func (t *Timer) Stop() bool {
+	tw := t.tw
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if t.bucket == nil {
+		return false
+	}
+	t.bucket.remove(t)
+	tw.size--
+	return true
+}
+
+// Advance 将时间轮推进到 clock 的当前时间，并依次执行所有到期的定时器，返回执行的定时器数量，
+// 每处理一个到期的槽位需要 O(层数 × wheelSize) 的时间查找下一个非空槽位
+func (tw *TimingWheel) Advance() int {
+	tw.mu.Lock()
+	expired := tw.advance(int64(tw.clock.Now().Sub(tw.startTime)))
+	tw.mu.Unlock()
+	for _, t := range expired {
+		t.f()
+	}
+	return len(expired)
+}
+
+// Run 持续推进时间轮并执行到期的定时器，直到 ctx 被取消，返回 ctx.Err()
+func (tw *TimingWheel) Run(ctx context.Context) error {
+	for {
+		tw.Advance()
+
+		var timer ClockTimer
+		tw.mu.Lock()
+		tw.next = math.MaxInt64
+		if _, expiration, ok := tw.nextBucket(); ok {
+			tw.next = expiration
+			next := tw.startTime.Add(time.Duration(expiration))
+			timer = tw.clock.NewTimer(next.Sub(tw.clock.Now()))
+		}
+		wait := tw.changed.wait()
+		tw.mu.Unlock()
+
+		err := waitTimer(ctx, wait, timer)
+		if err != nil {
+			tw.mu.Lock()
+			tw.next = math.MaxInt64
+			tw.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// add 将定时器放入合适的槽位，若该槽位早于 Run 正在等待的槽位到期则广播
+func (tw *TimingWheel) add(t *Timer) {
+	bucket, expiration := tw.wheel.bucketFor(t.expiration)
+	bucket.add(t)
+	if expiration < tw.next {
+		tw.next = expiration
+		tw.changed.broadcast()
+	}
+}
+
+// nextBucket 返回最早到期的非空槽位及其到期时间，若没有定时器则返回 false
+//
+//	每层的槽位从当前时间所在的槽位开始按到期时间排列，因此各层第一个非空槽位中最早的即为所求
+func (tw *TimingWheel) nextBucket() (*timerBucket, int64, bool) {
+	var next *timerBucket
+	var nextExpiration int64
+	for l := tw.wheel; l != nil; l = l.overflow {
+		if l.count == 0 {
+			continue
+		}
+		base := l.currentTime / l.tick
+		for i := int64(0); i < int64(len(l.buckets)); i++ {
+			bucket := l.buckets[(base+i)%int64(len(l.buckets))]
+			if bucket.root.next == &bucket.root {
+				continue
+			}
+			if expiration := (base + i) * l.tick; next == nil || expiration < nextExpiration {
+				next, nextExpiration = bucket, expiration
+			}
+			break
+		}
+	}
+	return next, nextExpiration, next != nil
+}
+
+// advance 推进到 now，将到期槽位中的定时器降级到更低层，返回所有已到期的定时器
+func (tw *TimingWheel) advance(now int64) []*Timer {
+	var expired []*Timer
+	for {
+		bucket, expiration, ok := tw.nextBucket()
+		if !ok || expiration > now {
+			break
+		}
+		tw.wheel.advanceClock(expiration)
+		for _, t := range bucket.flush() {
+			if t.expiration < tw.wheel.currentTime+tw.wheel.tick {
+				expired = append(expired, t)
+				tw.size--
+			} else {
+				tw.add(t)
+			}
+		}
+	}
+	tw.wheel.advanceClock(now)
+	return expired
+}
+
+// bucketFor 返回到期时间为 expiration 的定时器所属的槽位及该槽位的到期时间，必要时创建上一层时间轮
+func (l *timingWheelLevel) bucketFor(expiration int64) (*timerBucket, int64) {
+	for expiration >= l.currentTime+l.interval {
+		if l.overflow == nil {
+			l.overflow = newTimingWheelLevel(l.interval, len(l.buckets), l.currentTime)
+		}
+		l = l.overflow
+	}
+	virtualID := expiration / l.tick
+	return l.buckets[virtualID%int64(len(l.buckets))], virtualID * l.tick
+}
+
+// advanceClock 将本层及所有上层的当前时间推进到 t（按各层 tick 向下取整）
+func (l *timingWheelLevel) advanceClock(t int64) {
+	for ; l != nil; l = l.overflow {
+		if t >= l.currentTime+l.tick {
+			l.currentTime = t - t%l.tick
+		}
+	}
+}
+
+func newTimerBucket(level *timingWheelLevel) *timerBucket {
+	b := &timerBucket{level: level}
+	b.root.prev = &b.root
+	b.root.next = &b.root
+	return b
+}
+
+func (b *timerBucket) add(t *Timer) {
+	t.bucket = b
+	t.prev = b.root.prev
+	t.next = &b.root
+	t.prev.next = t
+	b.root.prev = t
+	b.level.count++
+}
+
+func (b *timerBucket) remove(t *Timer) {
+	t.prev.next = t.next
+	t.next.prev = t.prev
+	t.prev, t.next, t.bucket = nil, nil, nil
+	b.level.count--
+}
+
+// flush 清空槽位并返回其中的所有定时器
+func (b *timerBucket) flush() []*Timer {
+	var timers []*Timer
+	for t := b.root.next; t != &b.root; {
+		next := t.next
+		b.remove(t)
+		timers = append(timers, t)
+		t = next
+	}
+	return timers
+}
@@ -0,0 +1,111 @@
+package queue
+
+import "sync/atomic"
+
+// WorkStealingDeque 无锁的 Chase-Lev 工作窃取双端队列，适用于任务调度器：
+// 拥有者协程在队尾 Push/Pop（后进先出），其他协程可同时从队首 Steal（先进先出），
+// 环形数组在写满时自动扩容为两倍
+//
+//	Push 与 Pop 只能由拥有者协程调用，Steal 与 Len 可由任意协程调用
+type WorkStealingDeque[T any] struct {
+	_      cacheLinePad
+	top    atomic.Int64 // 下一个被窃取的位置，只增不减
+	_      cacheLinePad
+	bottom atomic.Int64 // 下一个 Push 的位置，仅由拥有者修改
+	_      cacheLinePad
+	buf    atomic.Pointer[wsBuffer[T]]
+}
+
+// wsBuffer 工作窃取双端队列的环形数组，扩容时替换为新数组，旧数组不再被修改
+type wsBuffer[T any] struct {
+	mask  int64
+	slots []atomic.Pointer[T]
+}
+
+// NewWorkStealingDeque 构造一个初始容量不小于 capacity 的工作窃取双端队列，capacity 小于 1 时 panic
+func NewWorkStealingDeque[T any](capacity int) *WorkStealingDeque[T] {
+	if capacity < 1 {
+		panic("queue.NewWorkStealingDeque: capacity must be positive")
+	}
+	q := &WorkStealingDeque[T]{}
+	q.buf.Store(newWSBuffer[T](int64(ceilPowerOfTwo(uint64(capacity)))))
+	return q
+}
+
+func newWSBuffer[T any](size int64) *wsBuffer[T] {
+	return &wsBuffer[T]{mask: size - 1, slots: make([]atomic.Pointer[T], size)}
+}
+
+// Len 获取队列中元素数量的近似值，并发修改时仅供参考
+func (q *WorkStealingDeque[T]) Len() int {
+	n := q.bottom.Load() - q.top.Load()
+	if n < 0 {
+		return 0
+	}
+	return int(n)
+}
+
+// Push 在队尾插入元素，只能由拥有者协程调用
+func (q *WorkStealingDeque[T]) Push(value T) {
+	b := q.bottom.Load()
+	t := q.top.Load()
+	a := q.buf.Load()
+	if b-t > a.mask {
+		a = a.grow(t, b)
+		q.buf.Store(a)
+	}
+	a.slots[b&a.mask].Store(&value)
+	q.bottom.Store(b + 1)
+}
+
+// Pop 删除并返回队尾元素，若队列为空则返回 false，只能由拥有者协程调用
+func (q *WorkStealingDeque[T]) Pop() (T, bool) {
+	var zero T
+	b := q.bottom.Load() - 1
+	a := q.buf.Load()
+	q.bottom.Store(b)
+	t := q.top.Load()
+	if t > b { // 队列为空
+		q.bottom.Store(b + 1)
+		return zero, false
+	}
+
+	slot := &a.slots[b&a.mask]
+	x := slot.Load()
+	if t == b { // 最后一个元素，与窃取者竞争
+		won := q.top.CompareAndSwap(t, t+1)
+		q.bottom.Store(b + 1)
+		if !won {
+			return zero, false
+		}
+	}
+	slot.Store(nil)
+	return *x, true
+}
+
+// Steal 删除并返回队首元素，若队列为空则返回 false，可由任意协程调用
+func (q *WorkStealingDeque[T]) Steal() (T, bool) {
+	for {
+		t := q.top.Load()
+		b := q.bottom.Load()
+		if t >= b {
+			var zero T
+			return zero, false
+		}
+		a := q.buf.Load()
+		x := a.slots[t&a.mask].Load()
+		if q.top.CompareAndSwap(t, t+1) {
+			return *x, true
+		}
+		// 与其他窃取者或拥有者竞争失败，重试
+	}
+}
+
+// grow 返回容量翻倍并复制了 [t, b) 中元素的新数组
+func (a *wsBuffer[T]) grow(t, b int64) *wsBuffer[T] {
+	na := newWSBuffer[T](2 * (a.mask + 1))
+	for i := t; i < b; i++ {
+		na.slots[i&na.mask].Store(a.slots[i&a.mask].Load())
+	}
+	return na
+}
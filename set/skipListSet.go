@@ -8,6 +8,8 @@ import (
 // SkipListSet 跳表实现的有序集合
 type SkipListSet[K any] list.SkipList[K, struct{}]
 
+var _ gostl.OrderedSet[int] = (*SkipListSet[int])(nil)
+
 // NewSkipListSet 构造一个空的有序集合
 func NewSkipListSet[K gostl.Ordered]() *SkipListSet[K] {
 	return (*SkipListSet[K])(list.NewSkipList[K, struct{}]())
@@ -58,6 +60,11 @@ func (s *SkipListSet[K]) Insert(key K) bool {
 	return s.Len() > oldLen
 }
 
+// TryInsert 同 Insert，用于实现 gostl.OrderedSet
+func (s *SkipListSet[K]) TryInsert(key K) bool {
+	return s.Insert(key)
+}
+
 // InsertN 向有序集合中插入若干键，返回成功插入的数量
 func (s *SkipListSet[K]) InsertN(keys ...K) int {
 	oldLen := s.Len()
@@ -81,6 +88,18 @@ func (s *SkipListSet[K]) RemoveN(keys ...K) int {
 	return oldLen - s.Len()
 }
 
+// LowerBound 返回有序集合中第一个不小于 key 的键，如果不存在，返回 false
+func (s *SkipListSet[K]) LowerBound(key K) (K, bool) {
+	k, v := s.asMap().LowerBound(key)
+	return k, v != nil
+}
+
+// UpperBound 返回有序集合中第一个大于 key 的键，如果不存在，返回 false
+func (s *SkipListSet[K]) UpperBound(key K) (K, bool) {
+	k, v := s.asMap().UpperBound(key)
+	return k, v != nil
+}
+
 // Keys 获取有序集合中所有键的切片，并按升序排序
 func (s *SkipListSet[K]) Keys() []K {
 	keys := make([]K, 0, s.Len())
@@ -0,0 +1,31 @@
+package set
+
+import (
+	"gostl/internal/containertest"
+	"testing"
+)
+
+func Test_OrderedSet(t *testing.T) {
+	containertest.CheckOrderedSet(t, "SkipListSet", NewSkipListSet[int]())
+	containertest.CheckOrderedSet(t, "SkipListSetFunc", NewSkipListSetFunc[int](func(a, b int) int { return a - b }))
+	containertest.CheckOrderedSet(t, "TreeSet", NewTreeSet[int]())
+	containertest.CheckOrderedSet(t, "TreeSetFunc", NewTreeSetFunc[int](func(a, b int) bool { return a < b }))
+}
+
+func Test_SkipListSetUpperBound(t *testing.T) {
+	s := NewSkipListInitializer(0, 3, 6, 9)
+	if _, ok := NewSkipListSet[int]().UpperBound(0); ok {
+		t.Fatal("UpperBound() on an empty set")
+	}
+	for i, want := range []int{3, 3, 3, 6, 6, 6, 9, 9, 9} {
+		if got, ok := s.UpperBound(i); !ok || got != want {
+			t.Fatalf("UpperBound(%d) = %d, %v, want %d", i, got, ok, want)
+		}
+	}
+	if got, ok := s.UpperBound(9); ok {
+		t.Fatalf("UpperBound(9) = %d, want none", got)
+	}
+	if got, ok := s.UpperBound(-1); !ok || got != 0 {
+		t.Fatalf("UpperBound(-1) = %d, %v, want 0", got, ok)
+	}
+}
@@ -0,0 +1,190 @@
+package set
+
+import (
+	"gostl"
+	"gostl/tree"
+)
+
+// TreeSet 红黑树实现的有序集合
+type TreeSet[K any] struct {
+	tree *tree.RBTree[K]
+	less gostl.LessFunc[K]
+}
+
+var _ gostl.OrderedSet[int] = (*TreeSet[int])(nil)
+
+// NewTreeSet 构造一个空的有序集合
+func NewTreeSet[K gostl.Ordered]() *TreeSet[K] {
+	return &TreeSet[K]{
+		tree: tree.NewRBTree[K](),
+		less: func(a, b K) bool { return a < b },
+	}
+}
+
+// NewTreeSetFunc 构造一个空的有序集合，并指定一个自定义的键值比较函数
+func NewTreeSetFunc[K any](less gostl.LessFunc[K]) *TreeSet[K] {
+	return &TreeSet[K]{
+		tree: tree.NewRBTreeFunc[K](less),
+		less: less,
+	}
+}
+
+// NewTreeSetInitializerList 构造一个有序集合并使用 initializerList 初始化
+func NewTreeSetInitializerList[K gostl.Ordered](keys ...K) *TreeSet[K] {
+	s := NewTreeSet[K]()
+	s.InsertN(keys...)
+	return s
+}
+
+// empty 构造一个与 s 使用相同比较函数的空集合
+func (s *TreeSet[K]) empty() *TreeSet[K] {
+	return NewTreeSetFunc[K](s.less)
+}
+
+// Empty 判断有序集合是否为空
+func (s *TreeSet[K]) Empty() bool {
+	return s.tree.Len() == 0
+}
+
+// Len 获取有序集合中元素的数量
+func (s *TreeSet[K]) Len() int {
+	return s.tree.Len()
+}
+
+// Clear 清空有序集合
+func (s *TreeSet[K]) Clear() {
+	s.tree.Clear()
+}
+
+// Exist 判断有序集合中是否存在指定键
+func (s *TreeSet[K]) Exist(key K) bool {
+	return s.tree.Exist(key)
+}
+
+// Insert 向有序集合中插入指定键，如果键已存在则返回 false
+func (s *TreeSet[K]) Insert(key K) bool {
+	return s.tree.TryInsert(key)
+}
+
+// TryInsert 同 Insert，用于实现 gostl.OrderedSet
+func (s *TreeSet[K]) TryInsert(key K) bool {
+	return s.Insert(key)
+}
+
+// InsertN 向有序集合中插入若干键，返回成功插入的数量
+func (s *TreeSet[K]) InsertN(keys ...K) int {
+	oldLen := s.Len()
+	for i := range keys {
+		s.tree.Insert(keys[i])
+	}
+	return s.Len() - oldLen
+}
+
+// Remove 删除有序集合中指定键，返回是否删除成功
+func (s *TreeSet[K]) Remove(key K) bool {
+	return s.tree.Remove(key)
+}
+
+// RemoveN 删除有序集合中若干键，返回成功删除的数量
+func (s *TreeSet[K]) RemoveN(keys ...K) int {
+	oldLen := s.Len()
+	for i := range keys {
+		s.tree.Remove(keys[i])
+	}
+	return oldLen - s.Len()
+}
+
+// LowerBound 返回有序集合中第一个不小于 key 的键，如果不存在，返回 false
+func (s *TreeSet[K]) LowerBound(key K) (K, bool) {
+	return s.tree.LowerBound(key)
+}
+
+// Min 返回有序集合中的最小键，若集合为空则返回零值
+func (s *TreeSet[K]) Min() K {
+	return s.tree.Min()
+}
+
+// Max 返回有序集合中的最大键，若集合为空则返回零值
+func (s *TreeSet[K]) Max() K {
+	return s.tree.Max()
+}
+
+// Keys 获取有序集合中所有键的切片，并按升序排序
+func (s *TreeSet[K]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	s.tree.ForEach(func(k K) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// ForEach 按升序遍历有序集合，并为每个元素执行 f 函数
+func (s *TreeSet[K]) ForEach(f func(key K)) {
+	s.tree.ForEach(f)
+}
+
+// ForEachIf 按升序遍历有序集合，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (s *TreeSet[K]) ForEachIf(f func(key K) bool) {
+	s.tree.ForEachIf(f)
+}
+
+// merge 线性归并两个集合的升序键序列，keep 根据键所属的集合决定是否保留该键，
+// 并由归并结果直接构造红黑树，时间复杂度 O(n+m)
+//
+//	inA、inB 分别表示键是否存在于 a、b 中
+func (s *TreeSet[K]) merge(other *TreeSet[K], keep func(inA, inB bool) bool) *TreeSet[K] {
+	a, b := s.Keys(), other.Keys()
+	keys := make([]K, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || i < len(a) && s.less(a[i], b[j]):
+			if keep(true, false) {
+				keys = append(keys, a[i])
+			}
+			i++
+		case i == len(a) || s.less(b[j], a[i]):
+			if keep(false, true) {
+				keys = append(keys, b[j])
+			}
+			j++
+		default:
+			if keep(true, true) {
+				keys = append(keys, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	res := s.empty()
+	res.tree.AssignSorted(keys)
+	return res
+}
+
+// Union 返回当前集合和 other 集合的并集，不修改当前集合，时间复杂度 O(n+m)
+func (s *TreeSet[K]) Union(other *TreeSet[K]) *TreeSet[K] {
+	return s.merge(other, func(inA, inB bool) bool {
+		return true
+	})
+}
+
+// Intersection 返回当前集合和 other 集合的交集，不修改当前集合，时间复杂度 O(n+m)
+func (s *TreeSet[K]) Intersection(other *TreeSet[K]) *TreeSet[K] {
+	return s.merge(other, func(inA, inB bool) bool {
+		return inA && inB
+	})
+}
+
+// Difference 返回当前集合和 other 集合的差集，不修改当前集合，时间复杂度 O(n+m)
+func (s *TreeSet[K]) Difference(other *TreeSet[K]) *TreeSet[K] {
+	return s.merge(other, func(inA, inB bool) bool {
+		return inA && !inB
+	})
+}
+
+// SymmetricDifference 返回当前集合和 other 集合的对称差集，不修改当前集合，时间复杂度 O(n+m)
+func (s *TreeSet[K]) SymmetricDifference(other *TreeSet[K]) *TreeSet[K] {
+	return s.merge(other, func(inA, inB bool) bool {
+		return inA != inB
+	})
+}
@@ -0,0 +1,60 @@
+package set
+
+import (
+	"gostl/internal/containertest"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomTreeSet(rander *rand.Rand, n int) (*TreeSet[int], map[int]bool) {
+	s, ref := NewTreeSet[int](), map[int]bool{}
+	for i := 0; i < n; i++ {
+		key := rander.Intn(200)
+		s.Insert(key)
+		ref[key] = true
+	}
+	return s, ref
+}
+
+func checkTreeSet(t *testing.T, name string, s *TreeSet[int], ref map[int]bool) {
+	t.Helper()
+	containertest.CheckKeys(t, name, s, ref)
+	if keys := s.Keys(); len(keys) != len(ref) || !sort.IntsAreSorted(keys) {
+		t.Fatalf("%s: Keys() = %v", name, keys)
+	}
+	// 结果集合仍需支持插入与删除
+	s.Insert(-1)
+	if !s.Exist(-1) || !s.Remove(-1) || s.Len() != len(ref) {
+		t.Fatalf("%s: Insert/Remove on the result", name)
+	}
+}
+
+func Test_TreeSetAlgebra(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		a, refA := randomTreeSet(rander, rander.Intn(100))
+		b, refB := randomTreeSet(rander, rander.Intn(100))
+		union, inter, diff, sym := map[int]bool{}, map[int]bool{}, map[int]bool{}, map[int]bool{}
+		for key := range refA {
+			union[key] = true
+			if refB[key] {
+				inter[key] = true
+			} else {
+				diff[key] = true
+				sym[key] = true
+			}
+		}
+		for key := range refB {
+			union[key] = true
+			if !refA[key] {
+				sym[key] = true
+			}
+		}
+		checkTreeSet(t, "Union", a.Union(b), union)
+		checkTreeSet(t, "Intersection", a.Intersection(b), inter)
+		checkTreeSet(t, "Difference", a.Difference(b), diff)
+		checkTreeSet(t, "SymmetricDifference", a.SymmetricDifference(b), sym)
+		checkTreeSet(t, "operand", a, refA)
+	}
+}
@@ -0,0 +1,67 @@
+package stack
+
+import "sync/atomic"
+
+// ConcurrentStack 无锁的并发安全栈（Treiber 栈），通过对链表头节点的 CAS 实现，
+// 节点由垃圾回收管理，不会被复用，因此不存在 ABA 问题
+type ConcurrentStack[T any] struct {
+	head atomic.Pointer[concurrentStackNode[T]]
+	size atomic.Int64
+}
+
+type concurrentStackNode[T any] struct {
+	value T
+	next  *concurrentStackNode[T]
+}
+
+// NewConcurrentStack 构造一个空 ConcurrentStack[T]
+func NewConcurrentStack[T any]() *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{}
+}
+
+// Len 返回栈中元素数量的近似值，并发修改时仅供参考
+func (stk *ConcurrentStack[T]) Len() int {
+	return int(max(stk.size.Load(), 0))
+}
+
+// Empty 返回栈是否为空，并发修改时仅供参考
+func (stk *ConcurrentStack[T]) Empty() bool {
+	return stk.head.Load() == nil
+}
+
+// Push 压入元素 value 到栈顶
+func (stk *ConcurrentStack[T]) Push(value T) {
+	node := &concurrentStackNode[T]{value: value}
+	for {
+		node.next = stk.head.Load()
+		if stk.head.CompareAndSwap(node.next, node) {
+			stk.size.Add(1)
+			return
+		}
+	}
+}
+
+// TryPop 弹出栈顶元素并返回，若栈为空则返回 false
+func (stk *ConcurrentStack[T]) TryPop() (T, bool) {
+	for {
+		head := stk.head.Load()
+		if head == nil {
+			var zero T
+			return zero, false
+		}
+		if stk.head.CompareAndSwap(head, head.next) {
+			stk.size.Add(-1)
+			return head.value, true
+		}
+	}
+}
+
+// Peek 返回栈顶元素但不弹出，若栈为空则返回 false
+func (stk *ConcurrentStack[T]) Peek() (T, bool) {
+	head := stk.head.Load()
+	if head == nil {
+		var zero T
+		return zero, false
+	}
+	return head.value, true
+}
@@ -0,0 +1,105 @@
+package stack
+
+import "gostl"
+
+// MinStack 支持 O(1) 获取栈中最小元素的栈
+type MinStack[T any] struct {
+	extremumStack[T]
+}
+
+// MaxStack 支持 O(1) 获取栈中最大元素的栈
+type MaxStack[T any] struct {
+	extremumStack[T]
+}
+
+// NewMinStack 构造一个空的可比较类型 MinStack[T]
+func NewMinStack[T gostl.Ordered]() *MinStack[T] {
+	return &MinStack[T]{newExtremumStack(func(a, b T) bool { return a < b })}
+}
+
+// NewMinStackFunc 基于比较函数 less 构造一个空的 MinStack[T]
+func NewMinStackFunc[T any](less gostl.LessFunc[T]) *MinStack[T] {
+	return &MinStack[T]{newExtremumStack(less)}
+}
+
+// NewMaxStack 构造一个空的可比较类型 MaxStack[T]
+func NewMaxStack[T gostl.Ordered]() *MaxStack[T] {
+	return &MaxStack[T]{newExtremumStack(func(a, b T) bool { return a > b })}
+}
+
+// NewMaxStackFunc 基于比较函数 less 构造一个空的 MaxStack[T]
+func NewMaxStackFunc[T any](less gostl.LessFunc[T]) *MaxStack[T] {
+	return &MaxStack[T]{newExtremumStack(func(a, b T) bool { return less(b, a) })}
+}
+
+// Min 返回栈中的最小元素，若栈为空则 panic
+func (stk *MinStack[T]) Min() T {
+	if stk.Empty() {
+		panic("MinStack.Min: empty stack")
+	}
+	return stk.extremums.Top()
+}
+
+// Max 返回栈中的最大元素，若栈为空则 panic
+func (stk *MaxStack[T]) Max() T {
+	if stk.Empty() {
+		panic("MaxStack.Max: empty stack")
+	}
+	return stk.extremums.Top()
+}
+
+// extremumStack MinStack 与 MaxStack 的公共实现，extremums 的栈顶为当前的最值，
+// 只有不劣于当前最值的元素才会压入 extremums，相等的元素也会压入，以便弹出时正确恢复
+type extremumStack[T any] struct {
+	elements  *Stack[T]
+	extremums *Stack[T]
+	before    gostl.LessFunc[T] // before(a, b) 表示 a 比 b 更适合作为最值
+}
+
+func newExtremumStack[T any](before gostl.LessFunc[T]) extremumStack[T] {
+	return extremumStack[T]{
+		elements:  NewStack[T](),
+		extremums: NewStack[T](),
+		before:    before,
+	}
+}
+
+// Empty 返回栈是否为空
+func (stk *extremumStack[T]) Empty() bool {
+	return stk.elements.Empty()
+}
+
+// Len 返回栈的长度
+func (stk *extremumStack[T]) Len() int {
+	return stk.elements.Len()
+}
+
+// Clear 清空栈
+func (stk *extremumStack[T]) Clear() {
+	stk.elements.Clear()
+	stk.extremums.Clear()
+}
+
+// Top 返回栈顶元素
+func (stk *extremumStack[T]) Top() T {
+	return stk.elements.Top()
+}
+
+// Push 依次压入若干个元素 values 到栈顶
+func (stk *extremumStack[T]) Push(values ...T) {
+	for _, value := range values {
+		if stk.extremums.Empty() || !stk.before(stk.extremums.Top(), value) {
+			stk.extremums.Push(value)
+		}
+		stk.elements.Push(value)
+	}
+}
+
+// Pop 弹出栈顶元素并返回
+func (stk *extremumStack[T]) Pop() T {
+	value := stk.elements.Pop()
+	if !stk.before(stk.extremums.Top(), value) {
+		stk.extremums.Pop()
+	}
+	return value
+}
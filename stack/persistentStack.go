@@ -0,0 +1,96 @@
+package stack
+
+// PersistentStack 不可变的持久化栈，基于共享尾部的单链表，
+// Push 与 Pop 返回新版本而不修改原版本，所有版本始终有效且共享公共部分，时间复杂度均为 O(1)，
+// 零值即为空栈，可安全地在多个协程间共享
+type PersistentStack[T any] struct {
+	head *persistentStackNode[T]
+}
+
+type persistentStackNode[T any] struct {
+	value T
+	next  *persistentStackNode[T]
+	size  int // 以该节点为栈顶的栈的长度
+}
+
+// NewPersistentStack 构造一个空 PersistentStack[T]
+func NewPersistentStack[T any]() PersistentStack[T] {
+	return PersistentStack[T]{}
+}
+
+// PersistentStackInitializerList 构造一个 PersistentStack[T] 并用 values 依次压栈
+func PersistentStackInitializerList[T any](values ...T) PersistentStack[T] {
+	stk := PersistentStack[T]{}
+	for _, value := range values {
+		stk = stk.Push(value)
+	}
+	return stk
+}
+
+// Empty 返回 stk 是否为空
+func (stk PersistentStack[T]) Empty() bool {
+	return stk.head == nil
+}
+
+// Len 返回 stk 的长度
+func (stk PersistentStack[T]) Len() int {
+	if stk.head == nil {
+		return 0
+	}
+	return stk.head.size
+}
+
+// Top 返回 stk 的栈顶元素，若 stk 为空则 panic
+func (stk PersistentStack[T]) Top() T {
+	if stk.head == nil {
+		panic("PersistentStack.Top: empty stack")
+	}
+	return stk.head.value
+}
+
+// Push 返回在 stk 的栈顶压入 value 后的新栈，stk 本身不变
+func (stk PersistentStack[T]) Push(value T) PersistentStack[T] {
+	return PersistentStack[T]{&persistentStackNode[T]{value, stk.head, stk.Len() + 1}}
+}
+
+// Pop 返回弹出 stk 的栈顶元素后的新栈，stk 本身不变，若 stk 为空则 panic
+func (stk PersistentStack[T]) Pop() PersistentStack[T] {
+	if stk.head == nil {
+		panic("PersistentStack.Pop: empty stack")
+	}
+	return PersistentStack[T]{stk.head.next}
+}
+
+// Reverse 返回元素顺序相反的新栈，时间复杂度 O(n)
+func (stk PersistentStack[T]) Reverse() PersistentStack[T] {
+	reversed := PersistentStack[T]{}
+	for node := stk.head; node != nil; node = node.next {
+		reversed = reversed.Push(node.value)
+	}
+	return reversed
+}
+
+// Values 从栈顶到栈底返回所有元素
+func (stk PersistentStack[T]) Values() []T {
+	values := make([]T, 0, stk.Len())
+	for node := stk.head; node != nil; node = node.next {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// ForEach 从栈顶到栈底遍历 stk，并为每个元素执行 f 函数
+func (stk PersistentStack[T]) ForEach(f func(value T)) {
+	for node := stk.head; node != nil; node = node.next {
+		f(node.value)
+	}
+}
+
+// ForEachIf 从栈顶到栈底遍历 stk，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (stk PersistentStack[T]) ForEachIf(f func(value T) bool) {
+	for node := stk.head; node != nil; node = node.next {
+		if !f(node.value) {
+			return
+		}
+	}
+}
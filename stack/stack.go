@@ -1,6 +1,9 @@
 package stack
 
-import "gostl/vector"
+import (
+	"gostl"
+	"gostl/vector"
+)
 
 // Stack 栈
 type Stack[T any] struct {
@@ -67,6 +70,21 @@ func (stk *Stack[T]) Pop() T {
 	return stk.elements.PopBack()
 }
 
+// Bottom 返回 stk 的栈底元素
+func (stk *Stack[T]) Bottom() T {
+	return stk.elements.Front()
+}
+
+// DropBottom 删除 stk 栈底的 n 个元素，n 大于栈的长度时清空 stk，时间复杂度 O(n)
+//
+//	只将栈底后移而不移动其余元素，被丢弃的空间在下一次扩容时回收，
+//	因此交替执行 Push 与 DropBottom(1) 的均摊时间复杂度为 O(1)
+func (stk *Stack[T]) DropBottom(n int) {
+	n = min(n, stk.elements.Len())
+	gostl.FillZero(stk.elements[:n])
+	stk.elements = stk.elements[n:]
+}
+
 // ForEach 遍历 stk，并为每个元素执行 f 函数
 func (stk *Stack[T]) ForEach(f func(value *T)) {
 	stk.elements.ForEach(f)
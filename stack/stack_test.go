@@ -0,0 +1,147 @@
+package stack
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_StackDropBottom(t *testing.T) {
+	stk := NewStack[int]()
+	stk.Push(1, 2, 3, 4, 5)
+	stk.DropBottom(0)
+	if stk.Len() != 5 {
+		t.Fatalf("DropBottom(0): Len() = %d, want 5", stk.Len())
+	}
+	stk.DropBottom(2)
+	var got []int
+	stk.ForEach(func(value *int) {
+		got = append(got, *value)
+	})
+	if len(got) != 3 || got[0] != 3 || got[2] != 5 || stk.Top() != 5 {
+		t.Fatalf("DropBottom(2) = %v, want [3 4 5]", got)
+	}
+	if stk.Pop() != 5 || stk.Pop() != 4 || stk.Len() != 1 {
+		t.Fatalf("Pop() after DropBottom(2)")
+	}
+	stk.DropBottom(10)
+	if !stk.Empty() {
+		t.Fatalf("DropBottom(10): Len() = %d, want 0", stk.Len())
+	}
+	stk.Push(6)
+	if stk.Top() != 6 || stk.Bottom() != 6 || stk.Len() != 1 {
+		t.Fatalf("Push() after DropBottom(10)")
+	}
+
+	// 保持固定深度的滑动窗口，被丢弃的空间需要被回收
+	const depth = 100
+	stk = NewStack[int]()
+	for i := 0; i < 100000; i++ {
+		stk.Push(i)
+		if stk.Len() > depth {
+			if stk.Bottom() != i-depth {
+				t.Fatalf("Bottom() = %d, want %d", stk.Bottom(), i-depth)
+			}
+			stk.DropBottom(1)
+		}
+	}
+	if stk.Len() != depth || stk.Bottom() != 100000-depth || stk.Cap() > 4*depth {
+		t.Fatalf("sliding window: Len() = %d, Bottom() = %d, Cap() = %d", stk.Len(), stk.Bottom(), stk.Cap())
+	}
+}
+
+func Test_MinMaxStack(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	minStk := NewMinStack[int]()
+	maxStk := NewMaxStackFunc[int](func(a, b int) bool { return a < b })
+	var ref []int
+	for i := 0; i < 5000; i++ {
+		if len(ref) == 0 || rander.Intn(3) > 0 {
+			v := rander.Intn(20)
+			minStk.Push(v)
+			maxStk.Push(v)
+			ref = append(ref, v)
+		} else {
+			want := ref[len(ref)-1]
+			ref = ref[:len(ref)-1]
+			if minStk.Pop() != want || maxStk.Pop() != want {
+				t.Fatalf("Pop() mismatch, want %d", want)
+			}
+		}
+		if len(ref) == 0 {
+			continue
+		}
+		lo, hi := ref[0], ref[0]
+		for _, v := range ref {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		if minStk.Min() != lo || maxStk.Max() != hi || minStk.Top() != ref[len(ref)-1] || minStk.Len() != len(ref) {
+			t.Fatalf("step %d: Min() = %d, Max() = %d, want %d, %d", i, minStk.Min(), maxStk.Max(), lo, hi)
+		}
+	}
+	minStk.Clear()
+	minStk.Push(3, 1, 2)
+	if minStk.Min() != 1 || minStk.Top() != 2 {
+		t.Fatalf("Push(3, 1, 2): Min() = %d, Top() = %d", minStk.Min(), minStk.Top())
+	}
+}
+
+func Test_ConcurrentStack(t *testing.T) {
+	stk := NewConcurrentStack[int]()
+	if _, ok := stk.TryPop(); ok {
+		t.Fatalf("TryPop() on an empty stack should fail")
+	}
+	stk.Push(1)
+	stk.Push(2)
+	if v, ok := stk.Peek(); !ok || v != 2 || stk.Len() != 2 {
+		t.Fatalf("Peek() = (%d, %v), Len() = %d", v, ok, stk.Len())
+	}
+	stk.TryPop()
+	stk.TryPop()
+
+	const workers, perWorker = 8, 2000
+	popped := make([]atomic.Int32, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				stk.Push(w*perWorker + i)
+				if i%2 == 1 {
+					if v, ok := stk.TryPop(); ok {
+						popped[v].Add(1)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for v, ok := stk.TryPop(); ok; v, ok = stk.TryPop() {
+		popped[v].Add(1)
+	}
+	for i := range popped {
+		if c := popped[i].Load(); c != 1 {
+			t.Fatalf("value %d popped %d times", i, c)
+		}
+	}
+	if !stk.Empty() || stk.Len() != 0 {
+		t.Fatalf("stack should be empty, Len() = %d", stk.Len())
+	}
+}
+
+func Test_PersistentStack(t *testing.T) {
+	empty := NewPersistentStack[int]()
+	a := PersistentStackInitializerList(1, 2, 3)
+	b := a.Pop().Push(4)
+	if a.Len() != 3 || a.Top() != 3 || b.Len() != 3 || b.Top() != 4 || !empty.Empty() {
+		t.Fatalf("a = %v, b = %v", a.Values(), b.Values())
+	}
+	if got := b.Reverse().Values(); got[0] != 1 || got[1] != 2 || got[2] != 4 {
+		t.Fatalf("Reverse() = %v", got)
+	}
+	if got := a.Values(); got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Fatalf("old version changed: %v", got)
+	}
+}
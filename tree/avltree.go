@@ -0,0 +1,424 @@
+package tree
+
+import "gostl"
+
+type avlNode[T any] struct {
+	left   *avlNode[T]
+	right  *avlNode[T]
+	parent *avlNode[T]
+	height int
+	value  T
+}
+
+// AVLTree AVL 树，平衡条件比红黑树更严格，适合读多写少的场景
+type AVLTree[T any] struct {
+	root  *avlNode[T]
+	count int
+	impl  avlImpl[T]
+}
+
+// NewAVLTree 构造一个可比较类型的 AVL 树
+func NewAVLTree[T gostl.Ordered]() *AVLTree[T] {
+	avl := avlTreeOrdered[T]{}
+	avl.impl = (avlImpl[T])(&avl)
+	return &avl.AVLTree
+}
+
+// NewAVLTreeFunc 基于比较函数 less 构造一个 AVL 树
+func NewAVLTreeFunc[T any](less gostl.LessFunc[T]) *AVLTree[T] {
+	avl := avlTreeFunc[T]{}
+	avl.less = less
+	avl.impl = (avlImpl[T])(&avl)
+	return &avl.AVLTree
+}
+
+func (n *avlNode[T]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *avlNode[T]) updateHeight() {
+	n.height = max(n.left.getHeight(), n.right.getHeight()) + 1
+}
+
+func (n *avlNode[T]) balanceFactor() int {
+	if n == nil {
+		return 0
+	}
+	return n.left.getHeight() - n.right.getHeight()
+}
+
+// replace 将 parent 中指向 old 的指针替换为 node
+func (t *AVLTree[T]) replace(parent, old, node *avlNode[T]) {
+	if node != nil {
+		node.parent = parent
+	}
+	if parent == nil {
+		t.root = node
+	} else if parent.left == old {
+		parent.left = node
+	} else {
+		parent.right = node
+	}
+}
+
+func (t *AVLTree[T]) leftRotate(x *avlNode[T]) *avlNode[T] {
+	//          |                                  |
+	//          X                                  Y
+	//         / \         left rotate            / \
+	//        α   Y       ------------->         X   γ
+	//           / \                            / \
+	//           β  γ                          α  β
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	t.replace(x.parent, x, y)
+	y.left = x
+	x.parent = y
+	x.updateHeight()
+	y.updateHeight()
+	return y
+}
+
+func (t *AVLTree[T]) rightRotate(x *avlNode[T]) *avlNode[T] {
+	//          |                                  |
+	//          X                                  Y
+	//         / \         right rotate           / \
+	//        Y   γ      ------------->         α  X
+	//       / \                                    / \
+	//      α  β                                   β  γ
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	t.replace(x.parent, x, y)
+	y.right = x
+	x.parent = y
+	x.updateHeight()
+	y.updateHeight()
+	return y
+}
+
+// rebalance 从 node 开始向上更新高度并旋转失衡的节点
+func (t *AVLTree[T]) rebalance(node *avlNode[T]) {
+	for node != nil {
+		node.updateHeight()
+		if bf := node.balanceFactor(); bf > 1 {
+			if node.left.balanceFactor() < 0 {
+				t.leftRotate(node.left)
+			}
+			node = t.rightRotate(node)
+		} else if bf < -1 {
+			if node.right.balanceFactor() > 0 {
+				t.rightRotate(node.right)
+			}
+			node = t.leftRotate(node)
+		}
+		node = node.parent
+	}
+}
+
+// Len 返回 AVL 树的节点数
+func (t *AVLTree[T]) Len() int {
+	return t.count
+}
+
+// Insert 往 AVL 树中插入元素
+func (t *AVLTree[T]) Insert(value T) {
+	t.TryInsert(value)
+}
+
+// TryInsert 往 AVL 树中插入元素，如果元素已经存在，返回 false
+func (t *AVLTree[T]) TryInsert(value T) bool {
+	node := &avlNode[T]{height: 1, value: value}
+	return t.impl.Insert(node) == node
+}
+
+// InsertOrGet 如果值不存在则插入，值存在则获取并返回
+func (t *AVLTree[T]) InsertOrGet(value T) T {
+	return t.impl.Insert(&avlNode[T]{height: 1, value: value}).value
+}
+
+// Delete 删除 AVL 树中的元素并返回
+func (t *AVLTree[T]) Delete(value T) T {
+	var zero T
+	z := t.impl.Search(value)
+	if z == nil {
+		return zero
+	}
+	ret := z.value
+
+	y := z
+	if z.left != nil && z.right != nil {
+		y = t.MinSub(z.right)
+		z.value = y.value
+	}
+
+	x := y.left
+	if x == nil {
+		x = y.right
+	}
+	parent := y.parent
+	t.replace(parent, y, x)
+	y.left, y.right, y.parent = nil, nil, nil
+	t.rebalance(parent)
+
+	t.count--
+	return ret
+}
+
+// Remove 删除 AVL 树中的元素，如果元素不存在，返回 false
+func (t *AVLTree[T]) Remove(value T) bool {
+	oldLen := t.count
+	t.Delete(value)
+	return t.count < oldLen
+}
+
+// Exist 判断 AVL 树中是否存在指定元素
+func (t *AVLTree[T]) Exist(value T) bool {
+	return t.impl.Search(value) != nil
+}
+
+// LowerBound 返回 AVL 树中第一个不小于 value 的元素，如果不存在，返回 false
+func (t *AVLTree[T]) LowerBound(value T) (T, bool) {
+	node := t.impl.lowerBound(value)
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Clear 清空 AVL 树
+func (t *AVLTree[T]) Clear() {
+	t.root = nil
+	t.count = 0
+}
+
+// ForEach 按升序遍历 AVL 树，并为每个元素执行 f 函数
+func (t *AVLTree[T]) ForEach(f func(value T)) {
+	for node := t.MinSub(t.root); node != nil; node = t.successor(node) {
+		f(node.value)
+	}
+}
+
+// ForEachIf 按升序遍历 AVL 树，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (t *AVLTree[T]) ForEachIf(f func(value T) bool) {
+	for node := t.MinSub(t.root); node != nil; node = t.successor(node) {
+		if !f(node.value) {
+			return
+		}
+	}
+}
+
+func (t *AVLTree[T]) successor(node *avlNode[T]) *avlNode[T] {
+	if node.right != nil {
+		return t.MinSub(node.right)
+	}
+
+	y := node.parent
+	for y != nil && node == y.right {
+		node = y
+		y = y.parent
+	}
+	return y
+}
+
+// Search 在 AVL 树中搜索元素，不存在时返回 nil
+func (t *AVLTree[T]) Search(value T) *avlNode[T] {
+	return t.impl.Search(value)
+}
+
+// Min 获取整个 AVL 树的最小值
+func (t *AVLTree[T]) Min() T {
+	var zero T
+	x := t.MinSub(t.root)
+	if x == nil {
+		return zero
+	}
+	return x.value
+}
+
+// Max 获取整个 AVL 树的最大值
+func (t *AVLTree[T]) Max() T {
+	var zero T
+	x := t.MaxSub(t.root)
+	if x == nil {
+		return zero
+	}
+	return x.value
+}
+
+// MinSub 返回 AVL 树中的以指定节点为根节点的子树的最小值
+func (t *AVLTree[T]) MinSub(node *avlNode[T]) *avlNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// MaxSub 返回 AVL 树中的以指定节点为根节点的子树的最大值
+func (t *AVLTree[T]) MaxSub(node *avlNode[T]) *avlNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Get 获取 AVL 树中的指定节点的值
+func (t *AVLTree[T]) Get(value T) T {
+	ret := t.impl.Search(value)
+	if ret == nil {
+		var zero T
+		return zero
+	}
+	return ret.value
+}
+
+type avlImpl[T any] interface {
+	Insert(node *avlNode[T]) *avlNode[T]
+	Search(value T) *avlNode[T]
+	lowerBound(value T) *avlNode[T]
+}
+
+type avlTreeOrdered[T gostl.Ordered] struct {
+	AVLTree[T]
+}
+
+func (t *avlTreeOrdered[T]) Insert(node *avlNode[T]) *avlNode[T] {
+	x := t.root
+	var y *avlNode[T]
+
+	for x != nil {
+		y = x
+		if node.value < x.value {
+			x = x.left
+		} else if x.value < node.value {
+			x = x.right
+		} else {
+			return x
+		}
+	}
+
+	node.parent = y
+	if y == nil {
+		t.root = node
+	} else if node.value < y.value {
+		y.left = node
+	} else {
+		y.right = node
+	}
+
+	t.count++
+	t.rebalance(y)
+	return node
+}
+
+func (t *avlTreeOrdered[T]) Search(value T) *avlNode[T] {
+	p := t.root
+
+	for p != nil {
+		if p.value < value {
+			p = p.right
+		} else if value < p.value {
+			p = p.left
+		} else {
+			break
+		}
+	}
+
+	return p
+}
+
+func (t *avlTreeOrdered[T]) lowerBound(value T) *avlNode[T] {
+	p := t.root
+	var ret *avlNode[T]
+
+	for p != nil {
+		if p.value < value {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
+
+type avlTreeFunc[T any] struct {
+	AVLTree[T]
+	less gostl.LessFunc[T]
+}
+
+func (t *avlTreeFunc[T]) Insert(node *avlNode[T]) *avlNode[T] {
+	x := t.root
+	var y *avlNode[T]
+
+	for x != nil {
+		y = x
+		if t.less(node.value, x.value) {
+			x = x.left
+		} else if t.less(x.value, node.value) {
+			x = x.right
+		} else {
+			return x
+		}
+	}
+
+	node.parent = y
+	if y == nil {
+		t.root = node
+	} else if t.less(node.value, y.value) {
+		y.left = node
+	} else {
+		y.right = node
+	}
+
+	t.count++
+	t.rebalance(y)
+	return node
+}
+
+func (t *avlTreeFunc[T]) Search(value T) *avlNode[T] {
+	p := t.root
+
+	for p != nil {
+		if t.less(p.value, value) {
+			p = p.right
+		} else if t.less(value, p.value) {
+			p = p.left
+		} else {
+			break
+		}
+	}
+
+	return p
+}
+
+func (t *avlTreeFunc[T]) lowerBound(value T) *avlNode[T] {
+	p := t.root
+	var ret *avlNode[T]
+
+	for p != nil {
+		if t.less(p.value, value) {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,333 @@
+package tree
+
+import (
+	"math/rand"
+	"time"
+)
+
+type implicitNode[T any] struct {
+	left     *implicitNode[T]
+	right    *implicitNode[T]
+	priority uint64
+	size     int  // 以当前节点为根的子树的节点数
+	reversed bool // 子树的左右孩子尚未交换的翻转标记
+	value    T
+	sum      T // 子树按正序的区间聚合值
+	rsum     T // 子树按逆序的区间聚合值
+}
+
+// ImplicitTreap 隐式键树堆，以元素在序列中的下标为键，
+// 支持 O(log n) 的任意位置插入、删除、分裂、拼接、区间翻转与区间聚合
+type ImplicitTreap[T any] struct {
+	root    *implicitNode[T]
+	combine func(a, b T) T
+	rander  *rand.Rand
+}
+
+// NewImplicitTreap 构造一个空的隐式键树堆，不支持区间聚合
+func NewImplicitTreap[T any]() *ImplicitTreap[T] {
+	return &ImplicitTreap[T]{rander: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// NewImplicitTreapFunc 构造一个空的隐式键树堆，并使用 combine 作为区间聚合函数
+//
+//	combine 需满足结合律，不要求满足交换律
+func NewImplicitTreapFunc[T any](combine func(a, b T) T) *ImplicitTreap[T] {
+	return &ImplicitTreap[T]{
+		combine: combine,
+		rander:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// NewImplicitTreapInitializerList 构造一个隐式键树堆并用 initializerList 依次初始化，不支持区间聚合
+func NewImplicitTreapInitializerList[T any](values ...T) *ImplicitTreap[T] {
+	t := NewImplicitTreap[T]()
+	t.root = t.build(values)
+	return t
+}
+
+func (n *implicitNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// reverse 翻转以 n 为根的子树，孩子节点的交换延迟到 pushDown 时进行
+func (n *implicitNode[T]) reverse() {
+	if n == nil {
+		return
+	}
+	n.sum, n.rsum = n.rsum, n.sum
+	n.reversed = !n.reversed
+}
+
+func (n *implicitNode[T]) pushDown() {
+	if n.reversed {
+		n.left, n.right = n.right, n.left
+		n.left.reverse()
+		n.right.reverse()
+		n.reversed = false
+	}
+}
+
+func (t *ImplicitTreap[T]) update(n *implicitNode[T]) {
+	n.size = n.left.getSize() + n.right.getSize() + 1
+	if t.combine == nil {
+		return
+	}
+	n.sum, n.rsum = n.value, n.value
+	if n.left != nil {
+		n.sum = t.combine(n.left.sum, n.sum)
+		n.rsum = t.combine(n.rsum, n.left.rsum)
+	}
+	if n.right != nil {
+		n.sum = t.combine(n.sum, n.right.sum)
+		n.rsum = t.combine(n.right.rsum, n.rsum)
+	}
+}
+
+func (t *ImplicitTreap[T]) newNode(value T) *implicitNode[T] {
+	return &implicitNode[T]{priority: t.rander.Uint64(), size: 1, value: value, sum: value, rsum: value}
+}
+
+// build 以 O(len(values)) 的时间将 values 构建为一棵子树
+func (t *ImplicitTreap[T]) build(values []T) *implicitNode[T] {
+	stack := make([]*implicitNode[T], 0, 64)
+	for _, v := range values {
+		node := t.newNode(v)
+		var last *implicitNode[T]
+		for len(stack) > 0 && stack[len(stack)-1].priority < node.priority {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			t.update(last)
+		}
+		node.left = last
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = node
+		}
+		stack = append(stack, node)
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		t.update(stack[i])
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+	return stack[0]
+}
+
+// split 将子树分裂为前 k 个元素和其余元素
+func (t *ImplicitTreap[T]) split(n *implicitNode[T], k int) (*implicitNode[T], *implicitNode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	n.pushDown()
+	if n.left.getSize() < k {
+		l, r := t.split(n.right, k-n.left.getSize()-1)
+		n.right = l
+		t.update(n)
+		return n, r
+	}
+	l, r := t.split(n.left, k)
+	n.left = r
+	t.update(n)
+	return l, n
+}
+
+func (t *ImplicitTreap[T]) merge(a, b *implicitNode[T]) *implicitNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.pushDown()
+		a.right = t.merge(a.right, b)
+		t.update(a)
+		return a
+	}
+	b.pushDown()
+	b.left = t.merge(a, b.left)
+	t.update(b)
+	return b
+}
+
+func (t *ImplicitTreap[T]) checkRange(l, r int) {
+	if l < 0 || r > t.Len() || l > r {
+		panic("ImplicitTreap: index out of range")
+	}
+}
+
+// Len 返回序列的长度
+func (t *ImplicitTreap[T]) Len() int {
+	return t.root.getSize()
+}
+
+// Empty 判断序列是否为空
+func (t *ImplicitTreap[T]) Empty() bool {
+	return t.root == nil
+}
+
+// Clear 清空序列
+func (t *ImplicitTreap[T]) Clear() {
+	t.root = nil
+}
+
+// At 返回下标 idx 处的元素，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) At(idx int) T {
+	t.checkRange(idx, idx+1)
+	n := t.root
+	for {
+		n.pushDown()
+		if ls := n.left.getSize(); idx < ls {
+			n = n.left
+		} else if idx == ls {
+			return n.value
+		} else {
+			idx -= ls + 1
+			n = n.right
+		}
+	}
+}
+
+// Set 设置下标 idx 处的元素，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) Set(idx int, value T) {
+	t.checkRange(idx, idx+1)
+	t.set(t.root, idx, value)
+}
+
+func (t *ImplicitTreap[T]) set(n *implicitNode[T], idx int, value T) {
+	n.pushDown()
+	if ls := n.left.getSize(); idx < ls {
+		t.set(n.left, idx, value)
+	} else if idx == ls {
+		n.value = value
+	} else {
+		t.set(n.right, idx-ls-1, value)
+	}
+	t.update(n)
+}
+
+// InsertAt 在下标 idx 处依次插入若干个元素，时间复杂度 O(len(values) + log(t.Len()))
+func (t *ImplicitTreap[T]) InsertAt(idx int, values ...T) {
+	t.checkRange(idx, idx)
+	l, r := t.split(t.root, idx)
+	t.root = t.merge(t.merge(l, t.build(values)), r)
+}
+
+// PushBack 在序列尾部依次添加若干个元素
+func (t *ImplicitTreap[T]) PushBack(values ...T) {
+	t.root = t.merge(t.root, t.build(values))
+}
+
+// PushFront 在序列头部依次添加若干个元素
+func (t *ImplicitTreap[T]) PushFront(values ...T) {
+	t.root = t.merge(t.build(values), t.root)
+}
+
+// RemoveAt 删除并返回下标 idx 处的元素，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) RemoveAt(idx int) T {
+	t.checkRange(idx, idx+1)
+	l, r := t.split(t.root, idx)
+	m, r := t.split(r, 1)
+	t.root = t.merge(l, r)
+	return m.value
+}
+
+// RemoveRange 删除序列中 [l, r) 之间的元素，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) RemoveRange(l, r int) {
+	t.checkRange(l, r)
+	a, b := t.split(t.root, l)
+	_, c := t.split(b, r-l)
+	t.root = t.merge(a, c)
+}
+
+// Slice 返回序列中 [l, r) 之间的元素的拷贝，时间复杂度 O(r - l + log(t.Len()))
+func (t *ImplicitTreap[T]) Slice(l, r int) []T {
+	t.checkRange(l, r)
+	a, b := t.split(t.root, l)
+	m, c := t.split(b, r-l)
+	ret := make([]T, 0, r-l)
+	implicitForEach(m, func(value T) bool {
+		ret = append(ret, value)
+		return true
+	})
+	t.root = t.merge(t.merge(a, m), c)
+	return ret
+}
+
+// Split 将序列从下标 idx 处分裂，t 保留 [0, idx) 之间的元素，返回由其余元素构成的新序列，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) Split(idx int) *ImplicitTreap[T] {
+	t.checkRange(idx, idx)
+	other := &ImplicitTreap[T]{
+		combine: t.combine,
+		rander:  rand.New(rand.NewSource(t.rander.Int63())),
+	}
+	t.root, other.root = t.split(t.root, idx)
+	return other
+}
+
+// Concat 将 other 拼接到序列尾部并清空 other，时间复杂度 O(log(t.Len()+other.Len()))
+func (t *ImplicitTreap[T]) Concat(other *ImplicitTreap[T]) {
+	t.root = t.merge(t.root, other.root)
+	other.root = nil
+}
+
+// Reverse 翻转序列中 [l, r) 之间的元素，时间复杂度 O(log(t.Len()))
+func (t *ImplicitTreap[T]) Reverse(l, r int) {
+	t.checkRange(l, r)
+	a, b := t.split(t.root, l)
+	m, c := t.split(b, r-l)
+	m.reverse()
+	t.root = t.merge(t.merge(a, m), c)
+}
+
+// Query 返回序列中 [l, r) 之间的元素按顺序聚合的结果，时间复杂度 O(log(t.Len()))
+//
+//	若未指定聚合函数或区间为空则 panic
+func (t *ImplicitTreap[T]) Query(l, r int) T {
+	if t.combine == nil {
+		panic("ImplicitTreap: combine function is not specified")
+	}
+	if l >= r {
+		panic("ImplicitTreap: empty range")
+	}
+	t.checkRange(l, r)
+	a, b := t.split(t.root, l)
+	m, c := t.split(b, r-l)
+	ret := m.sum
+	t.root = t.merge(t.merge(a, m), c)
+	return ret
+}
+
+// Values 返回序列中所有元素的拷贝
+func (t *ImplicitTreap[T]) Values() []T {
+	ret := make([]T, 0, t.Len())
+	t.ForEach(func(value T) {
+		ret = append(ret, value)
+	})
+	return ret
+}
+
+// ForEach 按顺序遍历序列，并为每个元素执行 f 函数
+func (t *ImplicitTreap[T]) ForEach(f func(value T)) {
+	implicitForEach(t.root, func(value T) bool {
+		f(value)
+		return true
+	})
+}
+
+// ForEachIf 按顺序遍历序列，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (t *ImplicitTreap[T]) ForEachIf(f func(value T) bool) {
+	implicitForEach(t.root, f)
+}
+
+func implicitForEach[T any](n *implicitNode[T], f func(value T) bool) bool {
+	if n == nil {
+		return true
+	}
+	n.pushDown()
+	return implicitForEach(n.left, f) && f(n.value) && implicitForEach(n.right, f)
+}
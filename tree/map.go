@@ -0,0 +1,112 @@
+package tree
+
+import "gostl"
+
+type mapEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// Map 基于红黑树实现的有序映射，与 list.SkipList 同样实现了 gostl.OrderedMap 接口
+//
+//	Find、LowerBound 与 ForEach 返回的值的引用在下一次删除操作前有效
+type Map[K any, V any] struct {
+	tree *RBTree[mapEntry[K, V]]
+}
+
+// NewMap 构造一个键为可比较类型的有序映射
+func NewMap[K gostl.Ordered, V any]() *Map[K, V] {
+	return &Map[K, V]{
+		tree: NewRBTreeFunc(func(a, b mapEntry[K, V]) bool {
+			return a.key < b.key
+		}),
+	}
+}
+
+// NewMapFunc 构造一个有序映射，并使用 less 作为键的比较函数
+func NewMapFunc[K any, V any](less gostl.LessFunc[K]) *Map[K, V] {
+	return &Map[K, V]{
+		tree: NewRBTreeFunc(func(a, b mapEntry[K, V]) bool {
+			return less(a.key, b.key)
+		}),
+	}
+}
+
+// Empty 判断有序映射是否为空
+func (m *Map[K, V]) Empty() bool {
+	return m.tree.Len() == 0
+}
+
+// Len 获取有序映射中键值对的数量
+func (m *Map[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// Clear 清空有序映射
+func (m *Map[K, V]) Clear() {
+	m.tree.Clear()
+}
+
+// Insert 往有序映射中插入一对键值对
+//
+//	如果键已经存在，则更新对应的值
+func (m *Map[K, V]) Insert(key K, value V) {
+	t := m.tree
+	node := &rbNode[mapEntry[K, V]]{
+		left:   t.nilNode,
+		right:  t.nilNode,
+		parent: t.nilNode,
+		color:  RED,
+		value:  mapEntry[K, V]{key, value},
+	}
+	if ret := t.impl.Insert(node); ret != node {
+		ret.value.value = value
+	}
+}
+
+// Find 返回指定键对应的值的引用，如果键不存在，返回 nil
+func (m *Map[K, V]) Find(key K) *V {
+	node := m.tree.Search(mapEntry[K, V]{key: key})
+	if node == m.tree.nilNode {
+		return nil
+	}
+	return &node.value.value
+}
+
+// Exist 判断有序映射中是否存在指定键
+func (m *Map[K, V]) Exist(key K) bool {
+	return m.tree.Exist(mapEntry[K, V]{key: key})
+}
+
+// Remove 删除有序映射中指定键值对，如果键值对不存在，返回 false
+func (m *Map[K, V]) Remove(key K) bool {
+	return m.tree.Remove(mapEntry[K, V]{key: key})
+}
+
+// LowerBound 返回第一个不小于 key 的键及其值的引用，如果不存在，值的引用为 nil
+func (m *Map[K, V]) LowerBound(key K) (K, *V) {
+	node := m.tree.impl.lowerBound(mapEntry[K, V]{key: key})
+	if node == m.tree.nilNode {
+		var zero K
+		return zero, nil
+	}
+	return node.value.key, &node.value.value
+}
+
+// ForEach 按键升序遍历有序映射，并为每个键值对执行 f 函数
+func (m *Map[K, V]) ForEach(f func(key K, value *V)) {
+	t := m.tree
+	for node := t.MinSub(t.root); node != t.nilNode; node = t.successor(node) {
+		f(node.value.key, &node.value.value)
+	}
+}
+
+// ForEachIf 按键升序遍历有序映射，并为每个键值对执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (m *Map[K, V]) ForEachIf(f func(key K, value *V) bool) {
+	t := m.tree
+	for node := t.MinSub(t.root); node != t.nilNode; node = t.successor(node) {
+		if !f(node.value.key, &node.value.value) {
+			return
+		}
+	}
+}
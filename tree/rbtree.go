@@ -1,6 +1,9 @@
 package tree
 
-import "gostl"
+import (
+	"gostl"
+	"math/bits"
+)
 
 const (
 	RED   = true
@@ -126,13 +129,19 @@ func (t *RBTree[T]) Len() int {
 
 // Insert 往红黑树中插入元素
 func (t *RBTree[T]) Insert(value T) {
-	t.impl.Insert(&rbNode[T]{
+	t.TryInsert(value)
+}
+
+// TryInsert 往红黑树中插入元素，如果元素已经存在，返回 false
+func (t *RBTree[T]) TryInsert(value T) bool {
+	node := &rbNode[T]{
 		left:   t.nilNode,
 		right:  t.nilNode,
 		parent: t.nilNode,
 		color:  RED,
 		value:  value,
-	})
+	}
+	return t.impl.Insert(node) == node
 }
 
 // InsertOrGet 如果值不存在则插入，值存在则获取并返回
@@ -157,6 +166,81 @@ func (t *RBTree[T]) Delete(value T) T {
 	}).value
 }
 
+// Remove 删除红黑树中的元素，如果元素不存在，返回 false
+func (t *RBTree[T]) Remove(value T) bool {
+	return t.delete(&rbNode[T]{
+		left:   t.nilNode,
+		right:  t.nilNode,
+		parent: t.nilNode,
+		color:  RED,
+		value:  value,
+	}) != t.nilNode
+}
+
+// Exist 判断红黑树中是否存在指定元素
+func (t *RBTree[T]) Exist(value T) bool {
+	return t.Search(value) != t.nilNode
+}
+
+// LowerBound 返回红黑树中第一个不小于 value 的元素，如果不存在，返回 false
+func (t *RBTree[T]) LowerBound(value T) (T, bool) {
+	node := t.impl.lowerBound(value)
+	if node == t.nilNode {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Clear 清空红黑树
+func (t *RBTree[T]) Clear() {
+	t.root = t.nilNode
+	t.count = 0
+}
+
+// AssignSorted 用严格升序排列的 values 替换红黑树中的所有元素，直接构造平衡的红黑树，时间复杂度 O(n)
+func (t *RBTree[T]) AssignSorted(values []T) {
+	depth := bits.Len(uint(len(values))) - 1 // 最深一层的深度，只有这一层可能不满，将其染红
+	t.root = t.buildSorted(values, t.nilNode, 0, depth)
+	t.root.color = BLACK
+	t.count = len(values)
+}
+
+// buildSorted 以 values 的中位数为根递归构造子树，左右子树大小至多相差 1，因此所有叶子的深度相差至多 1
+func (t *RBTree[T]) buildSorted(values []T, parent *rbNode[T], depth, maxDepth int) *rbNode[T] {
+	if len(values) == 0 {
+		return t.nilNode
+	}
+	mid := len(values) / 2
+	node := &rbNode[T]{
+		parent: parent,
+		color:  BLACK,
+		value:  values[mid],
+	}
+	if depth == maxDepth {
+		node.color = RED
+	}
+	node.left = t.buildSorted(values[:mid], node, depth+1, maxDepth)
+	node.right = t.buildSorted(values[mid+1:], node, depth+1, maxDepth)
+	return node
+}
+
+// ForEach 按升序遍历红黑树，并为每个元素执行 f 函数
+func (t *RBTree[T]) ForEach(f func(value T)) {
+	for node := t.MinSub(t.root); node != t.nilNode; node = t.successor(node) {
+		f(node.value)
+	}
+}
+
+// ForEachIf 按升序遍历红黑树，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (t *RBTree[T]) ForEachIf(f func(value T) bool) {
+	for node := t.MinSub(t.root); node != t.nilNode; node = t.successor(node) {
+		if !f(node.value) {
+			return
+		}
+	}
+}
+
 // Search 在红黑树中搜索元素
 func (t *RBTree[T]) Search(value T) *rbNode[T] {
 	return t.impl.Search(&rbNode[T]{
@@ -200,7 +284,7 @@ func (t *RBTree[T]) insertFixup(node *rbNode[T]) {
 			} else { // y.color == BLACK
 				if node == node.parent.right {
 					node = node.parent
-					t.impl.Insert(node)
+					t.leftRotate(node)
 				}
 				node.parent.color = BLACK
 				node.parent.parent.color = RED
@@ -258,7 +342,7 @@ func (t *RBTree[T]) Get(value T) T {
 		color:  RED,
 		value:  value,
 	})
-	if ret == nil {
+	if ret == t.nilNode {
 		var zero T
 		return zero
 	}
@@ -386,6 +470,7 @@ func (t *RBTree[T]) deleteFixup(node *rbNode[T]) {
 type rbImpl[T any] interface {
 	Insert(node *rbNode[T]) *rbNode[T]
 	Search(node *rbNode[T]) *rbNode[T]
+	lowerBound(value T) *rbNode[T]
 }
 
 type rbTreeOrdered[T gostl.Ordered] struct {
@@ -437,6 +522,22 @@ func (t *rbTreeOrdered[T]) Search(node *rbNode[T]) *rbNode[T] {
 	return p
 }
 
+func (t *rbTreeOrdered[T]) lowerBound(value T) *rbNode[T] {
+	p := t.root
+	ret := t.nilNode
+
+	for p != t.nilNode {
+		if p.value < value {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
+
 type rbTreeFunc[T any] struct {
 	RBTree[T]
 	less gostl.LessFunc[T]
@@ -486,3 +587,19 @@ func (t *rbTreeFunc[T]) Search(node *rbNode[T]) *rbNode[T] {
 
 	return p
 }
+
+func (t *rbTreeFunc[T]) lowerBound(value T) *rbNode[T] {
+	p := t.root
+	ret := t.nilNode
+
+	for p != t.nilNode {
+		if t.less(p.value, value) {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,401 @@
+package tree
+
+import "gostl"
+
+type splayNode[T any] struct {
+	left   *splayNode[T]
+	right  *splayNode[T]
+	parent *splayNode[T]
+	size   int // 以当前节点为根的子树的节点数
+	value  T
+}
+
+// SplayTree 伸展树，每次访问都会将被访问的节点旋转至根节点，
+// 访问分布越集中，热点元素的访问代价越低
+type SplayTree[T any] struct {
+	root *splayNode[T]
+	impl splayImpl[T]
+}
+
+// NewSplayTree 构造一个可比较类型的伸展树
+func NewSplayTree[T gostl.Ordered]() *SplayTree[T] {
+	t := splayTreeOrdered[T]{}
+	t.impl = (splayImpl[T])(&t)
+	return &t.SplayTree
+}
+
+// NewSplayTreeFunc 基于比较函数 less 构造一个伸展树
+func NewSplayTreeFunc[T any](less gostl.LessFunc[T]) *SplayTree[T] {
+	t := splayTreeFunc[T]{}
+	t.less = less
+	t.impl = (splayImpl[T])(&t)
+	return &t.SplayTree
+}
+
+func (n *splayNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *splayNode[T]) update() {
+	n.size = n.left.getSize() + n.right.getSize() + 1
+}
+
+// rotate 将 x 旋转至其父节点的位置
+func (t *SplayTree[T]) rotate(x *splayNode[T]) {
+	p := x.parent
+	g := p.parent
+	if p.left == x {
+		p.left = x.right
+		if x.right != nil {
+			x.right.parent = p
+		}
+		x.right = p
+	} else {
+		p.right = x.left
+		if x.left != nil {
+			x.left.parent = p
+		}
+		x.left = p
+	}
+	p.parent = x
+	x.parent = g
+	p.update()
+	x.update()
+
+	if g == nil {
+		t.root = x
+	} else if g.left == p {
+		g.left = x
+	} else {
+		g.right = x
+	}
+}
+
+// splay 将 x 伸展至根节点
+func (t *SplayTree[T]) splay(x *splayNode[T]) {
+	for x.parent != nil {
+		p := x.parent
+		if g := p.parent; g != nil {
+			if (g.left == p) == (p.left == x) { // zig-zig
+				t.rotate(p)
+			} else { // zig-zag
+				t.rotate(x)
+			}
+		}
+		t.rotate(x)
+	}
+}
+
+// Len 返回伸展树的节点数
+func (t *SplayTree[T]) Len() int {
+	return t.root.getSize()
+}
+
+// Insert 往伸展树中插入元素
+func (t *SplayTree[T]) Insert(value T) {
+	t.InsertOrGet(value)
+}
+
+// TryInsert 往伸展树中插入元素，如果元素已经存在，返回 false
+func (t *SplayTree[T]) TryInsert(value T) bool {
+	oldLen := t.Len()
+	t.InsertOrGet(value)
+	return t.Len() > oldLen
+}
+
+// InsertOrGet 如果值不存在则插入，值存在则获取并返回
+func (t *SplayTree[T]) InsertOrGet(value T) T {
+	last, cmp := t.impl.find(value)
+	if last != nil && cmp == 0 {
+		t.splay(last)
+		return last.value
+	}
+
+	node := &splayNode[T]{parent: last, size: 1, value: value}
+	if last == nil {
+		t.root = node
+	} else if cmp < 0 {
+		last.right = node
+	} else {
+		last.left = node
+	}
+	t.splay(node)
+	return value
+}
+
+// Delete 删除伸展树中的元素并返回
+func (t *SplayTree[T]) Delete(value T) T {
+	var zero T
+	last, cmp := t.impl.find(value)
+	if last == nil {
+		return zero
+	}
+	t.splay(last)
+	if cmp != 0 {
+		return zero
+	}
+
+	l, r := last.left, last.right
+	last.left, last.right = nil, nil
+	if l == nil {
+		t.root = r
+		if r != nil {
+			r.parent = nil
+		}
+	} else {
+		l.parent = nil
+		t.root = l
+		m := t.MaxSub(l)
+		t.splay(m)
+		m.right = r
+		if r != nil {
+			r.parent = m
+		}
+		m.update()
+	}
+	return last.value
+}
+
+// Remove 删除伸展树中的元素，如果元素不存在，返回 false
+func (t *SplayTree[T]) Remove(value T) bool {
+	oldLen := t.Len()
+	t.Delete(value)
+	return t.Len() < oldLen
+}
+
+// Exist 判断伸展树中是否存在指定元素，并将其伸展至根节点
+func (t *SplayTree[T]) Exist(value T) bool {
+	return t.Search(value) != nil
+}
+
+// LowerBound 返回伸展树中第一个不小于 value 的元素，如果不存在，返回 false
+func (t *SplayTree[T]) LowerBound(value T) (T, bool) {
+	var zero T
+	last, cmp := t.impl.find(value)
+	if last == nil {
+		return zero, false
+	}
+	t.splay(last)
+	if cmp < 0 {
+		last = t.successor(last)
+		if last == nil {
+			return zero, false
+		}
+	}
+	return last.value, true
+}
+
+// Clear 清空伸展树
+func (t *SplayTree[T]) Clear() {
+	t.root = nil
+}
+
+// ForEach 按升序遍历伸展树，并为每个元素执行 f 函数，遍历不会改变树的形状
+func (t *SplayTree[T]) ForEach(f func(value T)) {
+	for node := t.MinSub(t.root); node != nil; node = t.successor(node) {
+		f(node.value)
+	}
+}
+
+// ForEachIf 按升序遍历伸展树，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (t *SplayTree[T]) ForEachIf(f func(value T) bool) {
+	for node := t.MinSub(t.root); node != nil; node = t.successor(node) {
+		if !f(node.value) {
+			return
+		}
+	}
+}
+
+func (t *SplayTree[T]) successor(node *splayNode[T]) *splayNode[T] {
+	if node.right != nil {
+		return t.MinSub(node.right)
+	}
+
+	y := node.parent
+	for y != nil && node == y.right {
+		node = y
+		y = y.parent
+	}
+	return y
+}
+
+// Search 在伸展树中搜索元素并将其伸展至根节点，不存在时返回 nil
+func (t *SplayTree[T]) Search(value T) *splayNode[T] {
+	last, cmp := t.impl.find(value)
+	if last == nil {
+		return nil
+	}
+	t.splay(last)
+	if cmp != 0 {
+		return nil
+	}
+	return last
+}
+
+// Min 获取整个伸展树的最小值，并将其伸展至根节点
+func (t *SplayTree[T]) Min() T {
+	var zero T
+	x := t.MinSub(t.root)
+	if x == nil {
+		return zero
+	}
+	t.splay(x)
+	return x.value
+}
+
+// Max 获取整个伸展树的最大值，并将其伸展至根节点
+func (t *SplayTree[T]) Max() T {
+	var zero T
+	x := t.MaxSub(t.root)
+	if x == nil {
+		return zero
+	}
+	t.splay(x)
+	return x.value
+}
+
+// MinSub 返回伸展树中的以指定节点为根节点的子树的最小值
+func (t *SplayTree[T]) MinSub(node *splayNode[T]) *splayNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// MaxSub 返回伸展树中的以指定节点为根节点的子树的最大值
+func (t *SplayTree[T]) MaxSub(node *splayNode[T]) *splayNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Get 获取伸展树中的指定节点的值，并将其伸展至根节点
+func (t *SplayTree[T]) Get(value T) T {
+	ret := t.Search(value)
+	if ret == nil {
+		var zero T
+		return zero
+	}
+	return ret.value
+}
+
+// Split 按键分裂伸展树，t 保留所有小于 value 的元素，返回由其余元素构成的新伸展树，均摊时间复杂度 O(log(t.Len()))
+func (t *SplayTree[T]) Split(value T) *SplayTree[T] {
+	other := t.impl.empty()
+	last, cmp := t.impl.find(value)
+	if last == nil {
+		return other
+	}
+	t.splay(last)
+
+	if cmp < 0 { // last 为 value 的前驱，右子树中的元素均不小于 value
+		other.root = last.right
+		last.right = nil
+	} else {
+		other.root = last
+		t.root = last.left
+		last.left = nil
+	}
+	last.update()
+	if t.root != nil {
+		t.root.parent = nil
+	}
+	if other.root != nil {
+		other.root.parent = nil
+	}
+	return other
+}
+
+// Join 将 other 合并到 t 中并清空 other，要求 t 中所有元素均小于 other 中的元素，均摊时间复杂度 O(log(t.Len()+other.Len()))
+func (t *SplayTree[T]) Join(other *SplayTree[T]) {
+	if t.root == nil {
+		t.root = other.root
+	} else if other.root != nil {
+		m := t.MaxSub(t.root)
+		t.splay(m)
+		m.right = other.root
+		other.root.parent = m
+		m.update()
+	}
+	other.root = nil
+}
+
+type splayImpl[T any] interface {
+	// find 查找 value，返回查找路径上的最后一个节点及其与 value 的比较结果
+	find(value T) (*splayNode[T], int)
+	empty() *SplayTree[T]
+}
+
+type splayTreeOrdered[T gostl.Ordered] struct {
+	SplayTree[T]
+}
+
+func (t *splayTreeOrdered[T]) find(value T) (*splayNode[T], int) {
+	var last *splayNode[T]
+	p := t.root
+
+	for p != nil {
+		last = p
+		if p.value < value {
+			p = p.right
+		} else if value < p.value {
+			p = p.left
+		} else {
+			return p, 0
+		}
+	}
+
+	if last == nil || last.value < value {
+		return last, -1
+	}
+	return last, 1
+}
+
+func (t *splayTreeOrdered[T]) empty() *SplayTree[T] {
+	other := splayTreeOrdered[T]{}
+	other.impl = (splayImpl[T])(&other)
+	return &other.SplayTree
+}
+
+type splayTreeFunc[T any] struct {
+	SplayTree[T]
+	less gostl.LessFunc[T]
+}
+
+func (t *splayTreeFunc[T]) find(value T) (*splayNode[T], int) {
+	var last *splayNode[T]
+	p := t.root
+
+	for p != nil {
+		last = p
+		if t.less(p.value, value) {
+			p = p.right
+		} else if t.less(value, p.value) {
+			p = p.left
+		} else {
+			return p, 0
+		}
+	}
+
+	if last == nil || t.less(last.value, value) {
+		return last, -1
+	}
+	return last, 1
+}
+
+func (t *splayTreeFunc[T]) empty() *SplayTree[T] {
+	other := splayTreeFunc[T]{}
+	other.less = t.less
+	other.impl = (splayImpl[T])(&other)
+	return &other.SplayTree
+}
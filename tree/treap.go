@@ -0,0 +1,359 @@
+package tree
+
+import (
+	"gostl"
+	"math/rand"
+	"time"
+)
+
+type treapNode[T any] struct {
+	left     *treapNode[T]
+	right    *treapNode[T]
+	priority uint64
+	size     int // 以当前节点为根的子树的节点数
+	value    T
+}
+
+// Treap 树堆，按键有序，按随机优先级满足堆性质，支持按键分裂与合并
+type Treap[T any] struct {
+	root   *treapNode[T]
+	rander *rand.Rand
+	impl   treapImpl[T]
+}
+
+// NewTreap 构造一个可比较类型的树堆
+func NewTreap[T gostl.Ordered]() *Treap[T] {
+	t := treapOrdered[T]{}
+	t.rander = rand.New(rand.NewSource(time.Now().UnixNano()))
+	t.impl = (treapImpl[T])(&t)
+	return &t.Treap
+}
+
+// NewTreapFunc 基于比较函数 less 构造一个树堆
+func NewTreapFunc[T any](less gostl.LessFunc[T]) *Treap[T] {
+	t := treapFunc[T]{}
+	t.rander = rand.New(rand.NewSource(time.Now().UnixNano()))
+	t.less = less
+	t.impl = (treapImpl[T])(&t)
+	return &t.Treap
+}
+
+func (n *treapNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treapNode[T]) update() {
+	n.size = n.left.getSize() + n.right.getSize() + 1
+}
+
+// treapMerge 合并两棵子树，要求 a 中所有元素均小于 b 中的元素
+func treapMerge[T any](a, b *treapNode[T]) *treapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = treapMerge(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = treapMerge(a, b.left)
+	b.update()
+	return b
+}
+
+// Len 返回树堆的节点数
+func (t *Treap[T]) Len() int {
+	return t.root.getSize()
+}
+
+// Insert 往树堆中插入元素
+func (t *Treap[T]) Insert(value T) {
+	t.insert(value)
+}
+
+// TryInsert 往树堆中插入元素，如果元素已经存在，返回 false
+func (t *Treap[T]) TryInsert(value T) bool {
+	return t.insert(value) == nil
+}
+
+// InsertOrGet 如果值不存在则插入，值存在则获取并返回
+func (t *Treap[T]) InsertOrGet(value T) T {
+	if node := t.insert(value); node != nil {
+		return node.value
+	}
+	return value
+}
+
+// insert 插入元素，如果元素已经存在，返回已存在的节点
+func (t *Treap[T]) insert(value T) *treapNode[T] {
+	if node := t.impl.search(value); node != nil {
+		return node
+	}
+	node := &treapNode[T]{priority: t.rander.Uint64(), size: 1, value: value}
+	l, r := t.impl.split(t.root, value, false)
+	t.root = treapMerge(treapMerge(l, node), r)
+	return nil
+}
+
+// Delete 删除树堆中的元素并返回
+func (t *Treap[T]) Delete(value T) T {
+	var zero T
+	l, r := t.impl.split(t.root, value, false)
+	m, r := t.impl.split(r, value, true)
+	t.root = treapMerge(l, r)
+	if m == nil {
+		return zero
+	}
+	return m.value
+}
+
+// Remove 删除树堆中的元素，如果元素不存在，返回 false
+func (t *Treap[T]) Remove(value T) bool {
+	oldLen := t.Len()
+	t.Delete(value)
+	return t.Len() < oldLen
+}
+
+// Exist 判断树堆中是否存在指定元素
+func (t *Treap[T]) Exist(value T) bool {
+	return t.impl.search(value) != nil
+}
+
+// LowerBound 返回树堆中第一个不小于 value 的元素，如果不存在，返回 false
+func (t *Treap[T]) LowerBound(value T) (T, bool) {
+	node := t.impl.lowerBound(value)
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Clear 清空树堆
+func (t *Treap[T]) Clear() {
+	t.root = nil
+}
+
+// ForEach 按升序遍历树堆，并为每个元素执行 f 函数
+func (t *Treap[T]) ForEach(f func(value T)) {
+	treapForEach(t.root, func(value T) bool {
+		f(value)
+		return true
+	})
+}
+
+// ForEachIf 按升序遍历树堆，并为每个元素执行 f 函数，若其中一个 f 函数返回 false，直接返回
+func (t *Treap[T]) ForEachIf(f func(value T) bool) {
+	treapForEach(t.root, f)
+}
+
+func treapForEach[T any](n *treapNode[T], f func(value T) bool) bool {
+	if n == nil {
+		return true
+	}
+	return treapForEach(n.left, f) && f(n.value) && treapForEach(n.right, f)
+}
+
+// Search 在树堆中搜索元素，不存在时返回 nil
+func (t *Treap[T]) Search(value T) *treapNode[T] {
+	return t.impl.search(value)
+}
+
+// Min 获取整个树堆的最小值
+func (t *Treap[T]) Min() T {
+	var zero T
+	x := t.MinSub(t.root)
+	if x == nil {
+		return zero
+	}
+	return x.value
+}
+
+// Max 获取整个树堆的最大值
+func (t *Treap[T]) Max() T {
+	var zero T
+	x := t.MaxSub(t.root)
+	if x == nil {
+		return zero
+	}
+	return x.value
+}
+
+// MinSub 返回树堆中的以指定节点为根节点的子树的最小值
+func (t *Treap[T]) MinSub(node *treapNode[T]) *treapNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// MaxSub 返回树堆中的以指定节点为根节点的子树的最大值
+func (t *Treap[T]) MaxSub(node *treapNode[T]) *treapNode[T] {
+	if node == nil {
+		return nil
+	}
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Get 获取树堆中的指定节点的值
+func (t *Treap[T]) Get(value T) T {
+	ret := t.impl.search(value)
+	if ret == nil {
+		var zero T
+		return zero
+	}
+	return ret.value
+}
+
+// Split 按键分裂树堆，t 保留所有小于 value 的元素，返回由其余元素构成的新树堆，时间复杂度 O(log(t.Len()))
+func (t *Treap[T]) Split(value T) *Treap[T] {
+	other := t.impl.empty()
+	t.root, other.root = t.impl.split(t.root, value, false)
+	return other
+}
+
+// Merge 将 other 合并到 t 中并清空 other，要求 t 中所有元素均小于 other 中的元素，时间复杂度 O(log(t.Len()+other.Len()))
+func (t *Treap[T]) Merge(other *Treap[T]) {
+	t.root = treapMerge(t.root, other.root)
+	other.root = nil
+}
+
+type treapImpl[T any] interface {
+	search(value T) *treapNode[T]
+	lowerBound(value T) *treapNode[T]
+	// split 将子树分裂为小于 value（inclusive 为 true 时为小于等于）的部分和其余部分
+	split(node *treapNode[T], value T, inclusive bool) (*treapNode[T], *treapNode[T])
+	empty() *Treap[T]
+}
+
+type treapOrdered[T gostl.Ordered] struct {
+	Treap[T]
+}
+
+func (t *treapOrdered[T]) search(value T) *treapNode[T] {
+	p := t.root
+
+	for p != nil {
+		if p.value < value {
+			p = p.right
+		} else if value < p.value {
+			p = p.left
+		} else {
+			break
+		}
+	}
+
+	return p
+}
+
+func (t *treapOrdered[T]) lowerBound(value T) *treapNode[T] {
+	p := t.root
+	var ret *treapNode[T]
+
+	for p != nil {
+		if p.value < value {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
+
+func (t *treapOrdered[T]) split(node *treapNode[T], value T, inclusive bool) (*treapNode[T], *treapNode[T]) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.value < value || inclusive && !(value < node.value) {
+		l, r := t.split(node.right, value, inclusive)
+		node.right = l
+		node.update()
+		return node, r
+	}
+	l, r := t.split(node.left, value, inclusive)
+	node.left = r
+	node.update()
+	return l, node
+}
+
+func (t *treapOrdered[T]) empty() *Treap[T] {
+	other := treapOrdered[T]{}
+	other.rander = rand.New(rand.NewSource(t.rander.Int63()))
+	other.impl = (treapImpl[T])(&other)
+	return &other.Treap
+}
+
+type treapFunc[T any] struct {
+	Treap[T]
+	less gostl.LessFunc[T]
+}
+
+func (t *treapFunc[T]) search(value T) *treapNode[T] {
+	p := t.root
+
+	for p != nil {
+		if t.less(p.value, value) {
+			p = p.right
+		} else if t.less(value, p.value) {
+			p = p.left
+		} else {
+			break
+		}
+	}
+
+	return p
+}
+
+func (t *treapFunc[T]) lowerBound(value T) *treapNode[T] {
+	p := t.root
+	var ret *treapNode[T]
+
+	for p != nil {
+		if t.less(p.value, value) {
+			p = p.right
+		} else {
+			ret = p
+			p = p.left
+		}
+	}
+
+	return ret
+}
+
+func (t *treapFunc[T]) split(node *treapNode[T], value T, inclusive bool) (*treapNode[T], *treapNode[T]) {
+	if node == nil {
+		return nil, nil
+	}
+	if t.less(node.value, value) || inclusive && !t.less(value, node.value) {
+		l, r := t.split(node.right, value, inclusive)
+		node.right = l
+		node.update()
+		return node, r
+	}
+	l, r := t.split(node.left, value, inclusive)
+	node.left = r
+	node.update()
+	return l, node
+}
+
+func (t *treapFunc[T]) empty() *Treap[T] {
+	other := treapFunc[T]{}
+	other.rander = rand.New(rand.NewSource(t.rander.Int63()))
+	other.less = t.less
+	other.impl = (treapImpl[T])(&other)
+	return &other.Treap
+}
@@ -0,0 +1,23 @@
+package tree
+
+import "gostl"
+
+// Tree 有序树的公共接口，RBTree、AVLTree、Treap 与 SplayTree 均实现该接口，便于在不同实现之间切换
+type Tree[T any] interface {
+	gostl.OrderedSet[T]
+	Insert(value T)
+	InsertOrGet(value T) T
+	Delete(value T) T
+	Get(value T) T
+	Min() T
+	Max() T
+}
+
+var (
+	_ Tree[int] = (*RBTree[int])(nil)
+	_ Tree[int] = (*AVLTree[int])(nil)
+	_ Tree[int] = (*Treap[int])(nil)
+	_ Tree[int] = (*SplayTree[int])(nil)
+
+	_ gostl.OrderedMap[int, int] = (*Map[int, int])(nil)
+)
@@ -0,0 +1,290 @@
+package tree
+
+import (
+	"gostl/internal/containertest"
+	"math/rand"
+	"testing"
+)
+
+func Test_Tree(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for name, newTree := range map[string]func() Tree[int]{
+		"RBTree":        func() Tree[int] { return NewRBTree[int]() },
+		"RBTreeFunc":    func() Tree[int] { return NewRBTreeFunc[int](less) },
+		"AVLTree":       func() Tree[int] { return NewAVLTree[int]() },
+		"AVLTreeFunc":   func() Tree[int] { return NewAVLTreeFunc[int](less) },
+		"Treap":         func() Tree[int] { return NewTreap[int]() },
+		"TreapFunc":     func() Tree[int] { return NewTreapFunc[int](less) },
+		"SplayTree":     func() Tree[int] { return NewSplayTree[int]() },
+		"SplayTreeFunc": func() Tree[int] { return NewSplayTreeFunc[int](less) },
+	} {
+		containertest.CheckOrderedSet(t, name, newTree())
+
+		// 空树上的 Min、Max、Get、Delete 返回零值
+		tr := newTree()
+		if tr.Min() != 0 || tr.Max() != 0 || tr.Get(1) != 0 || tr.Delete(1) != 0 || tr.Len() != 0 {
+			t.Fatalf("%s: Min/Max/Get/Delete on an empty tree", name)
+		}
+		var insert func(int) = tr.Insert
+		for _, v := range []int{5, 1, 9, 5} {
+			insert(v)
+		}
+		if tr.Len() != 3 || tr.Min() != 1 || tr.Max() != 9 || tr.Get(9) != 9 || tr.Get(4) != 0 {
+			t.Fatalf("%s: Len/Min/Max/Get after Insert", name)
+		}
+		if tr.InsertOrGet(7) != 7 || tr.Len() != 4 || tr.Delete(5) != 5 || tr.Delete(5) != 0 || tr.Len() != 3 {
+			t.Fatalf("%s: InsertOrGet/Delete", name)
+		}
+	}
+}
+
+// Test_TreeDuplicateKey 键相等的元素不会重复插入，InsertOrGet 与 Get 返回树中已有的元素
+func Test_TreeDuplicateKey(t *testing.T) {
+	type entry struct{ key, value int }
+	less := func(a, b entry) bool { return a.key < b.key }
+	for name, tr := range map[string]Tree[entry]{
+		"RBTreeFunc":    NewRBTreeFunc[entry](less),
+		"AVLTreeFunc":   NewAVLTreeFunc[entry](less),
+		"TreapFunc":     NewTreapFunc[entry](less),
+		"SplayTreeFunc": NewSplayTreeFunc[entry](less),
+	} {
+		tr.Insert(entry{1, 10})
+		if tr.TryInsert(entry{1, 20}) || tr.Len() != 1 {
+			t.Fatalf("%s: TryInsert of a duplicate key", name)
+		}
+		if got := tr.InsertOrGet(entry{1, 30}); got.value != 10 {
+			t.Fatalf("%s: InsertOrGet() = %v, want the existing entry", name, got)
+		}
+		if got := tr.Get(entry{1, 0}); got.value != 10 {
+			t.Fatalf("%s: Get() = %v, want the existing entry", name, got)
+		}
+		if got, ok := tr.LowerBound(entry{0, 0}); !ok || got.value != 10 {
+			t.Fatalf("%s: LowerBound() = %v, %v", name, got, ok)
+		}
+	}
+}
+
+func Test_Map(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Insert("b", 2)
+	m.Insert("a", 1)
+	m.Insert("c", 3)
+	m.Insert("b", 20)
+	if m.Len() != 3 || *m.Find("b") != 20 || m.Find("d") != nil {
+		t.Fatal("Map: Insert/Find")
+	}
+	if k, v := m.LowerBound("bb"); k != "c" || *v != 3 {
+		t.Fatalf("Map: LowerBound(bb) = %s", k)
+	}
+	if _, v := m.LowerBound("d"); v != nil {
+		t.Fatal("Map: LowerBound(d) should not exist")
+	}
+	*m.Find("a") = 10
+	keys := ""
+	m.ForEach(func(key string, value *int) {
+		keys += key
+	})
+	if keys != "abc" || !m.Remove("a") || m.Remove("a") || m.Len() != 2 {
+		t.Fatal("Map: ForEach/Remove")
+	}
+}
+
+// rbCheck 校验以 n 为根的子树的父指针、有序性、无连续红节点，返回黑高
+func rbCheck(t *testing.T, tr *RBTree[int], n *rbNode[int]) int {
+	if n == tr.nilNode {
+		return 1
+	}
+	if n.left != tr.nilNode && (n.left.parent != n || n.left.value >= n.value) ||
+		n.right != tr.nilNode && (n.right.parent != n || n.right.value <= n.value) {
+		t.Fatal("RBTree: broken parent pointer or order")
+	}
+	if n.color == RED && (n.left.color == RED || n.right.color == RED) {
+		t.Fatal("RBTree: red node with a red child")
+	}
+	l, r := rbCheck(t, tr, n.left), rbCheck(t, tr, n.right)
+	if l != r {
+		t.Fatal("RBTree: unequal black height")
+	}
+	if n.color == BLACK {
+		l++
+	}
+	return l
+}
+
+func Test_RBTreeBalance(t *testing.T) {
+	tr := NewRBTree[int]()
+	for i := 0; i < 1024; i++ {
+		tr.Insert(i)
+		if tr.root.color != BLACK {
+			t.Fatal("RBTree: red root")
+		}
+		rbCheck(t, tr, tr.root)
+	}
+	for i := 0; i < 1024; i += 2 {
+		tr.Delete(i)
+	}
+	rbCheck(t, tr, tr.root)
+}
+
+func Test_RBTreeAssignSorted(t *testing.T) {
+	for n := 0; n <= 100; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i * 2
+		}
+		tr := NewRBTree[int]()
+		tr.Insert(-1)
+		tr.AssignSorted(values)
+		if tr.Len() != n || tr.root.color != BLACK {
+			t.Fatalf("AssignSorted(%d values): Len() = %d", n, tr.Len())
+		}
+		rbCheck(t, tr, tr.root)
+		// 构造出的树仍需支持后续的插入与删除
+		for i := 0; i < n; i++ {
+			tr.Insert(i*2 + 1)
+			tr.Delete(i * 2)
+			rbCheck(t, tr, tr.root)
+		}
+		if tr.Len() != n {
+			t.Fatalf("Len() after updates = %d, want %d", tr.Len(), n)
+		}
+	}
+}
+
+func avlCheck[T any](t *testing.T, n *avlNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	if n.left != nil && n.left.parent != n || n.right != nil && n.right.parent != n {
+		t.Fatal("AVLTree: broken parent pointer")
+	}
+	l, r := avlCheck(t, n.left), avlCheck(t, n.right)
+	if l-r > 1 || r-l > 1 || n.height != max(l, r)+1 {
+		t.Fatal("AVLTree: unbalanced node")
+	}
+	return n.height
+}
+
+func Test_AVLTreeBalance(t *testing.T) {
+	tr := NewAVLTree[int]()
+	for i := 0; i < 1024; i++ {
+		tr.Insert(i)
+	}
+	avlCheck(t, tr.root)
+	if tr.root.height != 11 {
+		t.Fatalf("height = %d, want 11", tr.root.height)
+	}
+	for i := 0; i < 1024; i += 2 {
+		tr.Delete(i)
+	}
+	avlCheck(t, tr.root)
+}
+
+func Test_TreapSplitMerge(t *testing.T) {
+	tr := NewTreap[int]()
+	for i := 0; i < 100; i++ {
+		tr.Insert(i)
+	}
+	right := tr.Split(40)
+	if tr.Len() != 40 || right.Len() != 60 || tr.Max() != 39 || right.Min() != 40 {
+		t.Fatalf("Split: %d %d %d %d", tr.Len(), right.Len(), tr.Max(), right.Min())
+	}
+	tr.Merge(right)
+	if tr.Len() != 100 || right.Len() != 0 || tr.Get(40) != 40 {
+		t.Fatalf("Merge: %d %d", tr.Len(), right.Len())
+	}
+}
+
+func Test_SplayTreeSplitJoin(t *testing.T) {
+	tr := NewSplayTree[int]()
+	for i := 0; i < 100; i += 2 {
+		tr.Insert(i)
+	}
+	for _, key := range []int{-1, 0, 41, 50, 98, 99} {
+		right := tr.Split(key)
+		if tr.Len()+right.Len() != 50 || tr.Len() != (key+1)/2 {
+			t.Fatalf("Split(%d): %d %d", key, tr.Len(), right.Len())
+		}
+		if tr.Len() > 0 && tr.Max() >= key || right.Len() > 0 && right.Min() < key {
+			t.Fatalf("Split(%d): wrong partition", key)
+		}
+		tr.Join(right)
+		if tr.Len() != 50 || right.Len() != 0 {
+			t.Fatalf("Join: %d %d", tr.Len(), right.Len())
+		}
+	}
+	if tr.Search(42) == nil || tr.root.value != 42 {
+		t.Fatal("Search: accessed node is not splayed to the root")
+	}
+}
+
+func Test_ImplicitTreap(t *testing.T) {
+	rander := rand.New(rand.NewSource(1))
+	seq := NewImplicitTreapFunc[string](func(a, b string) string { return a + b })
+	ref := []string{}
+	for i := 0; i < 3000; i++ {
+		switch op := rander.Intn(5); {
+		case op <= 1 || len(ref) == 0:
+			idx := rander.Intn(len(ref) + 1)
+			v := string(rune('a' + rander.Intn(26)))
+			seq.InsertAt(idx, v, v)
+			ref = append(ref[:idx], append([]string{v, v}, ref[idx:]...)...)
+		case op == 2:
+			idx := rander.Intn(len(ref))
+			if got := seq.RemoveAt(idx); got != ref[idx] {
+				t.Fatalf("RemoveAt(%d) = %s, want %s", idx, got, ref[idx])
+			}
+			ref = append(ref[:idx], ref[idx+1:]...)
+		case op == 3:
+			l := rander.Intn(len(ref))
+			r := l + rander.Intn(len(ref)-l) + 1
+			seq.Reverse(l, r)
+			for i, j := l, r-1; i < j; i, j = i+1, j-1 {
+				ref[i], ref[j] = ref[j], ref[i]
+			}
+		default:
+			l := rander.Intn(len(ref))
+			r := l + rander.Intn(len(ref)-l) + 1
+			want := ""
+			for _, v := range ref[l:r] {
+				want += v
+			}
+			if got := seq.Query(l, r); got != want {
+				t.Fatalf("Query(%d, %d) = %s, want %s", l, r, got, want)
+			}
+		}
+	}
+
+	values := seq.Values()
+	for i := range ref {
+		if values[i] != ref[i] || seq.At(i) != ref[i] {
+			t.Fatalf("At(%d) = %s, want %s", i, seq.At(i), ref[i])
+		}
+	}
+	tail := seq.Split(10)
+	if seq.Len() != 10 || tail.Len() != len(ref)-10 || tail.At(0) != ref[10] {
+		t.Fatal("Split: wrong result")
+	}
+	seq.Concat(tail)
+	if got := seq.Slice(5, 15); len(got) != 10 || got[0] != ref[5] || got[9] != ref[14] {
+		t.Fatalf("Slice(5, 15) = %v", got)
+	}
+}
+
+func benchmarkTree(b *testing.B, tr Tree[int]) {
+	for i := 0; i < 1<<16; i++ {
+		tr.Insert(i * 7919 % (1 << 16))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.Get(i & (1<<16 - 1))
+	}
+}
+
+func Benchmark_RBTreeGet(b *testing.B) {
+	benchmarkTree(b, NewRBTree[int]())
+}
+
+func Benchmark_AVLTreeGet(b *testing.B) {
+	benchmarkTree(b, NewAVLTree[int]())
+}